@@ -3,20 +3,30 @@ package analyzer
 import (
 	"go/ast"
 	"go/token"
+	"reflect"
 	"strings"
 
 	"github.com/m-ocean-it/errgroup-ctx-lint/analyzer/func_visitor"
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 )
 
 const (
 	nolintDirective = "nolint"
-	nolintName      = "errgroupctx"
 	nolintAll       = "all"
 )
 
+const (
+	// Name is the name of the errgroup analyzer, also used in nolint
+	// comments.
+	Name = "errgroupctx"
+	// DerivedName is the name of the derived context analyzer, also used in
+	// nolint comments.
+	DerivedName = "derivedctx"
+)
+
 var DefaultConfig = func_visitor.Config{
 	ErrgroupPackagePaths: []string{
 		"golang.org/x/sync/errgroup",
@@ -29,10 +39,13 @@ func NewAnalyzerWithConfig(cfg func_visitor.Config) *analysis.Analyzer {
 
 func newAnalyzer(cfg func_visitor.Config) *analysis.Analyzer {
 	return &analysis.Analyzer{
-		Name:     "errgroupctx",
-		Doc:      "Checks that errgroup closures use the context derived from a corresponding errgroup",
-		Run:      Run(cfg),
-		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Name:       Name,
+		Doc:        "Checks that errgroup closures use the context derived from a corresponding errgroup",
+		URL:        func_visitor.DocsURL,
+		Run:        Run(cfg),
+		Requires:   []*analysis.Analyzer{inspect.Analyzer, ctrlflow.Analyzer},
+		ResultType: reflect.TypeOf([]func_visitor.GroupReport(nil)),
+		FactTypes:  []analysis.Fact{new(func_visitor.FactoryFact)},
 	}
 }
 
@@ -46,18 +59,56 @@ func Run(cfg func_visitor.Config) func(*analysis.Pass) (any, error) {
 				(*ast.DeclStmt)(nil),
 				(*ast.CallExpr)(nil),
 			}
-			nolintLines = getNolintLines(pass.Files, pass.Fset)
+			nolintLines = getNolintLines(pass.Files, pass.Fset, Name)
 		)
 
 		thisFuncVisitor := func_visitor.New(pass, nolintLines, cfg)
+		thisFuncVisitor.ExportFactoryFacts()
 
 		inspector.WithStack(nodeFilter, thisFuncVisitor.Visit)
+		thisFuncVisitor.CheckGroups()
+
+		return thisFuncVisitor.Groups(), nil
+	}
+}
+
+// NewDerivedAnalyzerWithConfig returns the derived context analyzer: it
+// reports goroutines, started by go statements or sync.WaitGroup.Go, that
+// reference the parent of a cancellable context derived before them by one of
+// cfg.DeriveFunctions.
+func NewDerivedAnalyzerWithConfig(cfg func_visitor.Config) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     DerivedName,
+		Doc:      "Checks that goroutines use the cancellable context derived before them rather than its parent",
+		URL:      func_visitor.DocsURL + "#" + func_visitor.RuleDerivedContext,
+		Run:      RunDerived(cfg),
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+}
+
+func RunDerived(cfg func_visitor.Config) func(*analysis.Pass) (any, error) {
+	return func(pass *analysis.Pass) (any, error) {
+		var (
+			inspector  = pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+			nodeFilter = []ast.Node{
+				(*ast.FuncDecl)(nil),
+				(*ast.BlockStmt)(nil),
+				(*ast.CaseClause)(nil),
+				(*ast.CommClause)(nil),
+				(*ast.AssignStmt)(nil),
+				(*ast.GoStmt)(nil),
+				(*ast.CallExpr)(nil),
+			}
+			nolintLines = getNolintLines(pass.Files, pass.Fset, DerivedName)
+		)
+
+		inspector.WithStack(nodeFilter, func_visitor.NewDerived(pass, nolintLines, cfg).Visit)
 
 		return nil, nil
 	}
 }
 
-func getNolintLines(files []*ast.File, fset *token.FileSet) map[func_visitor.CommentPosition]struct{} {
+func getNolintLines(files []*ast.File, fset *token.FileSet, name string) map[func_visitor.CommentPosition]struct{} {
 	var comments []*ast.CommentGroup
 	for _, f := range files {
 		comments = append(comments, f.Comments...)
@@ -65,7 +116,7 @@ func getNolintLines(files []*ast.File, fset *token.FileSet) map[func_visitor.Com
 
 	nolintLines := make(map[func_visitor.CommentPosition]struct{})
 	for _, comm := range comments {
-		if !commentIsNoLint(comm) {
+		if !commentIsNoLint(comm, name) {
 			continue
 		}
 
@@ -83,7 +134,7 @@ func getNolintLines(files []*ast.File, fset *token.FileSet) map[func_visitor.Com
 	return nolintLines
 }
 
-func commentIsNoLint(commentGroup *ast.CommentGroup) bool {
+func commentIsNoLint(commentGroup *ast.CommentGroup, name string) bool {
 	if commentGroup == nil || len(commentGroup.List) == 0 {
 		return false
 	}
@@ -113,7 +164,7 @@ func commentIsNoLint(commentGroup *ast.CommentGroup) bool {
 		}()
 
 		for _, nolintEntry := range nolintList {
-			if nolintEntry == nolintAll || nolintEntry == nolintName {
+			if nolintEntry == nolintAll || nolintEntry == name {
 				return true
 			}
 		}
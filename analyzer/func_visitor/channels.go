@@ -0,0 +1,295 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkBlockingChannels reports channel operations in an errgroup callback that
+// may block forever once a sibling callback fails, because they do not select
+// on the derived context's Done channel as well.
+func (fv *funcVisitor) checkBlockingChannels(funcLit *ast.FuncLit, elem *errgroupStackElement) {
+	if elem.ctxObj == nil {
+		return
+	}
+
+	fv.walkBlockingChannels(funcLit.Body, funcLit, elem)
+}
+
+func (fv *funcVisitor) walkBlockingChannels(root ast.Node, funcLit *ast.FuncLit, elem *errgroupStackElement) {
+	ast.PreorderStack(root, nil, func(n ast.Node, stack []ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Nested callbacks are checked on their own, goroutines do not
+			// block the callback.
+			if call, ok := parent(stack).(*ast.CallExpr); ok {
+				if tryGetErrgroupClosureFromCallExpr(call, fv.pass.TypesInfo, fv.cfg) == n {
+					return false
+				}
+
+				if _, ok := grandparent(stack).(*ast.GoStmt); ok {
+					return false
+				}
+			}
+		case *ast.SelectStmt:
+			if fv.selectObservesContext(n, funcLit, elem) {
+				// Only the communication clauses are excused, not the
+				// bodies of the cases.
+				for _, clause := range n.Body.List {
+					for _, stmt := range clause.(*ast.CommClause).Body {
+						fv.walkBlockingChannels(stmt, funcLit, elem)
+					}
+				}
+
+				return false
+			}
+		case *ast.SendStmt:
+			if fv.mayBlock(n.Chan) {
+				fv.reportBlockingChannel(n, "send on", n.Chan, elem)
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW && fv.mayBlock(n.X) {
+				fv.reportBlockingChannel(n, "receive from", n.X, elem)
+			}
+		case *ast.RangeStmt:
+			if isChanType(fv.pass.TypesInfo.TypeOf(n.X)) && fv.mayBlock(n.X) {
+				fv.reportBlockingChannel(n.X, "range over", n.X, elem)
+			}
+		}
+
+		return true
+	})
+}
+
+func (fv *funcVisitor) reportBlockingChannel(node ast.Node, op string, ch ast.Expr, elem *errgroupStackElement) {
+	fv.report(RuleBlockingChannel, node, nil,
+		"%s channel %q in errgroup callback may block forever, select on %s.Done() as well",
+		op, types.ExprString(ch), elem.ctxName)
+}
+
+// selectObservesContext reports whether the select statement cannot block
+// forever: it has a default case or waits for the group's context as well.
+func (fv *funcVisitor) selectObservesContext(selectStmt *ast.SelectStmt, funcLit *ast.FuncLit, elem *errgroupStackElement) bool {
+	for _, clause := range selectStmt.Body.List {
+		comm := clause.(*ast.CommClause).Comm
+		if comm == nil {
+			return true
+		}
+
+		var recv ast.Expr
+		switch comm := comm.(type) {
+		case *ast.ExprStmt:
+			recv = comm.X
+		case *ast.AssignStmt:
+			if len(comm.Rhs) == 1 {
+				recv = comm.Rhs[0]
+			}
+		}
+
+		unary, ok := ast.Unparen(recv).(*ast.UnaryExpr)
+		if !ok || unary.Op != token.ARROW {
+			continue
+		}
+
+		if ctxObj := fv.doneChannelContext(unary.X); ctxObj != nil {
+			// The group's own context, or one derived from it inside the
+			// callback.
+			if ctxObj == elem.ctxObj || (ctxObj.Pos() >= funcLit.Pos() && ctxObj.Pos() < funcLit.End()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// doneChannelContext returns the context variable if the expression is a call
+// to its Done method.
+func (fv *funcVisitor) doneChannelContext(expr ast.Expr) types.Object {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Done" {
+		return nil
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	obj := fv.pass.TypesInfo.Uses[ident]
+	if obj == nil || !isContextType(obj.Type()) {
+		return nil
+	}
+
+	return obj
+}
+
+// mayBlock reports whether an operation on the channel may block forever.
+// Channels known to be buffered, timer channels and Done channels of contexts
+// are assumed not to.
+func (fv *funcVisitor) mayBlock(ch ast.Expr) bool {
+	if fv.isClockOrDone(ch) {
+		return false
+	}
+
+	if ident, ok := ast.Unparen(ch).(*ast.Ident); ok {
+		if made := fv.channelMake(ident); made != nil {
+			return !makesBufferedChannel(made, fv.pass.TypesInfo)
+		}
+	}
+
+	return true
+}
+
+// isClockOrDone reports whether the channel is the Done channel of a context
+// or a time channel: it is closed or sent to by the runtime, not by another
+// goroutine.
+func (fv *funcVisitor) isClockOrDone(ch ast.Expr) bool {
+	return fv.doneChannelContext(ch) != nil || fv.isTimeChannel(ch)
+}
+
+// isTimeChannel reports whether the channel comes from the time package: it is
+// returned by a function such as time.Tick or time.After, or is the C field of
+// a timer or a ticker.
+func (fv *funcVisitor) isTimeChannel(ch ast.Expr) bool {
+	switch ch := ast.Unparen(ch).(type) {
+	case *ast.CallExpr:
+		fn, ok := typeutil.Callee(fv.pass.TypesInfo, ch).(*types.Func)
+		return ok && fn.Pkg() != nil && fn.Pkg().Path() == "time"
+	case *ast.SelectorExpr:
+		return ch.Sel.Name == "C" && isTimePackageType(fv.pass.TypesInfo.TypeOf(ch.X))
+	}
+
+	return false
+}
+
+// channelMake finds the make call a channel variable is initialized with.
+func (fv *funcVisitor) channelMake(ident *ast.Ident) *ast.CallExpr {
+	obj := fv.pass.TypesInfo.Uses[ident]
+	if obj == nil || !obj.Pos().IsValid() {
+		return nil
+	}
+
+	for _, n := range fv.pathTo(obj.Pos()) {
+		var lhs, rhs []ast.Expr
+
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			lhs, rhs = n.Lhs, n.Rhs
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				lhs = append(lhs, name)
+			}
+			rhs = n.Values
+		default:
+			continue
+		}
+
+		if len(lhs) != len(rhs) {
+			return nil
+		}
+
+		for i, e := range lhs {
+			if id, ok := e.(*ast.Ident); ok && fv.pass.TypesInfo.Defs[id] == obj {
+				call, _ := ast.Unparen(rhs[i]).(*ast.CallExpr)
+				if call != nil && isBuiltin(fv.pass.TypesInfo, call, "make") {
+					return call
+				}
+
+				return nil
+			}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// pathTo returns the nodes enclosing the position, innermost first.
+func (fv *funcVisitor) pathTo(pos token.Pos) []ast.Node {
+	for _, file := range fv.pass.Files {
+		if file.Pos() <= pos && pos < file.End() {
+			path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+			return path
+		}
+	}
+
+	return nil
+}
+
+func makesBufferedChannel(made *ast.CallExpr, typesInfo *types.Info) bool {
+	if len(made.Args) < 2 {
+		return false
+	}
+
+	tv, ok := typesInfo.Types[made.Args[1]]
+	if !ok || tv.Value == nil {
+		// Not a constant, assume the capacity is positive.
+		return true
+	}
+
+	return constant.Sign(tv.Value) > 0
+}
+
+func isBuiltin(typesInfo *types.Info, call *ast.CallExpr, name string) bool {
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	b, ok := typesInfo.Uses[ident].(*types.Builtin)
+
+	return ok && b.Name() == name
+}
+
+func isTimePackageType(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == "time"
+}
+
+func isChanType(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	_, ok := typ.Underlying().(*types.Chan)
+
+	return ok
+}
+
+func parent(stack []ast.Node) ast.Node {
+	if len(stack) < 1 {
+		return nil
+	}
+
+	return stack[len(stack)-1]
+}
+
+func grandparent(stack []ast.Node) ast.Node {
+	if len(stack) < 2 {
+		return nil
+	}
+
+	return stack[len(stack)-2]
+}
@@ -1,11 +1,98 @@
 package func_visitor
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
 
 const DefaultPkgPath = "golang.org/x/sync/errgroup"
 
+// Spawner is a function or method, other than the errgroup's own Go and TryGo,
+// that runs a callback in a new goroutine.
+type Spawner struct {
+	// Func is the spawner as "pkg/path.Func" or "pkg/path.Type.Method".
+	Func string `json:"func"`
+	// Arg is the index of the callback among the arguments.
+	Arg int `json:"arg"`
+}
+
+// DefaultSpawners are the secondary spawners checked by default.
+var DefaultSpawners = []Spawner{
+	{Func: "sync.WaitGroup.Go", Arg: 0},
+}
+
+// DefaultDeriveFunctions are the functions returning a cancellable context
+// derived from their first argument, as checked by the derived context
+// analyzer.
+var DefaultDeriveFunctions = []string{
+	"context.WithCancel",
+	"context.WithCancelCause",
+	"context.WithDeadline",
+	"context.WithDeadlineCause",
+	"context.WithTimeout",
+	"context.WithTimeoutCause",
+	"os/signal.NotifyContext",
+}
+
 type Config struct {
 	ErrgroupPackagePaths []string `json:"errgroup_package_paths"`
+
+	// Enable turns on rules that are off by default.
+	Enable []string `json:"enable"`
+	// Disable turns off rules, including the ones that are on by default.
+	Disable []string `json:"disable"`
+
+	// AggregateByCallback makes the linter emit a single diagnostic per
+	// callback, listing every outer context it references, instead of one
+	// diagnostic per reference.
+	AggregateByCallback bool `json:"aggregate_by_callback"`
+
+	// CheckTryGoWithoutLimit makes the trygo-result rule also report groups
+	// without a SetLimit call, where TryGo never fails.
+	CheckTryGoWithoutLimit bool `json:"check_trygo_without_limit"`
+
+	// CancellationAwareCalls lists functions, as "pkg/path.Func" or
+	// "pkg/path.Type.Method", that observe the context on their own. Loops
+	// calling them are not reported by the uncancellable-loop rule.
+	CancellationAwareCalls []string `json:"cancellation_aware_calls"`
+
+	// FanOutThreshold is the number of iterations above which loops of a
+	// constant size, e.g. ranging over an array, are reported by the
+	// unbounded-fanout rule. Zero exempts them.
+	FanOutThreshold int64 `json:"fan_out_threshold"`
+
+	// Spawners lists secondary spawners. Their callbacks, when passed within
+	// the scope of an errgroup, are checked against the innermost errgroup's
+	// derived context just like Go and TryGo callbacks. DefaultSpawners by
+	// default.
+	Spawners []Spawner `json:"spawners"`
+
+	// DeriveFunctions lists functions, as "pkg/path.Func", that return a
+	// cancellable context derived from their first argument. They are used
+	// by the derived context analyzer, DefaultDeriveFunctions by default.
+	DeriveFunctions []string `json:"derive_functions"`
+
+	// MessageTemplate replaces the text of outer context diagnostics. It is
+	// a text/template executed against MessageData.
+	MessageTemplate string `json:"message_template"`
+
+	// Explain is a "file:line" position. When set, the visitor describes how
+	// every errgroup callback covering that line was analyzed.
+	Explain string `json:"-"`
+	// ExplainOutput receives the explanations, os.Stderr by default.
+	ExplainOutput io.Writer `json:"-"`
+
+	enabledRules    map[string]bool
+	explainFile     string
+	explainLine     int
+	messageTemplate *template.Template
 }
 
 func (c *Config) Prepare() error {
@@ -21,5 +108,71 @@ func (c *Config) Prepare() error {
 		}
 	}
 
+	if c.Spawners == nil {
+		c.Spawners = DefaultSpawners
+	}
+
+	for _, spawner := range c.Spawners {
+		if spawner.Func == "" || spawner.Arg < 0 {
+			return fmt.Errorf("invalid spawner %q with callback argument %d", spawner.Func, spawner.Arg)
+		}
+	}
+
+	if len(c.DeriveFunctions) == 0 {
+		c.DeriveFunctions = DefaultDeriveFunctions
+	}
+
+	c.enabledRules = maps.Clone(defaultRules)
+
+	for _, rule := range c.Enable {
+		if _, ok := defaultRules[rule]; !ok {
+			return fmt.Errorf("unknown rule %q, known rules: %s", rule, strings.Join(Rules(), ", "))
+		}
+
+		c.enabledRules[rule] = true
+	}
+
+	for _, rule := range c.Disable {
+		if _, ok := defaultRules[rule]; !ok {
+			return fmt.Errorf("unknown rule %q, known rules: %s", rule, strings.Join(Rules(), ", "))
+		}
+
+		c.enabledRules[rule] = false
+	}
+
+	if c.MessageTemplate != "" {
+		tmpl, err := parseMessageTemplate(c.MessageTemplate)
+		if err != nil {
+			return err
+		}
+
+		c.messageTemplate = tmpl
+	}
+
+	if c.Explain != "" {
+		sep := strings.LastIndex(c.Explain, ":")
+		if sep <= 0 {
+			return fmt.Errorf("explain position %q is not in the file:line form", c.Explain)
+		}
+
+		line, err := strconv.Atoi(c.Explain[sep+1:])
+		if err != nil || line <= 0 {
+			return fmt.Errorf("explain position %q has an invalid line", c.Explain)
+		}
+
+		c.explainFile = filepath.ToSlash(filepath.Clean(c.Explain[:sep]))
+		c.explainLine = line
+
+		if c.ExplainOutput == nil {
+			c.ExplainOutput = os.Stderr
+		}
+	}
+
 	return nil
 }
+
+// RuleEnabled reports whether the rule runs under this configuration. It is
+// only meaningful after Prepare.
+func (c *Config) RuleEnabled(rule string) bool {
+	return c.enabledRules[rule]
+}
@@ -0,0 +1,171 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/types"
+	"log"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// derivedVisitor generalizes the outer context check to any cancellable
+// context: after "ctx2, cancel := context.WithCancel(ctx)", goroutines
+// started by go statements or sync.WaitGroup.Go should use ctx2, not ctx.
+type derivedVisitor struct {
+	*funcVisitor
+
+	contexts errgroupStack
+}
+
+// NewDerived creates the visitor of the derived context analyzer.
+func NewDerived(
+	pass *analysis.Pass,
+	nolintLines map[CommentPosition]struct{},
+	cfg Config,
+) *derivedVisitor {
+	if err := cfg.Prepare(); err != nil {
+		log.Fatalf("invalid config: %s", err)
+	}
+
+	return &derivedVisitor{
+		funcVisitor: &funcVisitor{
+			cfg:         cfg,
+			pass:        pass,
+			nolintLines: nolintLines,
+		},
+	}
+}
+
+func (dv *derivedVisitor) Visit(node ast.Node, push bool, stack []ast.Node) bool {
+	if !dv.cfg.RuleEnabled(RuleDerivedContext) {
+		return false
+	}
+
+	if node == nil || !push {
+		dv.contexts = dv.contexts.Trim(len(stack))
+
+		return false
+	}
+
+	switch n := node.(type) {
+	case *ast.AssignStmt:
+		dv.visitDerive(n, stack)
+	case *ast.GoStmt:
+		dv.checkGoroutine(n.Call)
+	case *ast.CallExpr:
+		if dv.isWaitGroupGo(n) && len(n.Args) == 1 {
+			dv.checkGoroutine(n.Args[0])
+		}
+	}
+
+	return true
+}
+
+// visitDerive pushes the context derived by the assignment, if any.
+func (dv *derivedVisitor) visitDerive(assignStmt *ast.AssignStmt, stack []ast.Node) {
+	if len(assignStmt.Rhs) != 1 || len(assignStmt.Lhs) == 0 {
+		return
+	}
+
+	call, ok := assignStmt.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return
+	}
+
+	fn, ok := typeutil.Callee(dv.pass.TypesInfo, call).(*types.Func)
+	if !ok || !slices.Contains(dv.cfg.DeriveFunctions, qualifiedFuncName(fn)) {
+		return
+	}
+
+	ctxIdent, ok := assignStmt.Lhs[0].(*ast.Ident)
+	if !ok || ctxIdent.Name == "_" {
+		return
+	}
+
+	parentIdent, ok := ast.Unparen(call.Args[0]).(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	var (
+		ctxObj    = dv.pass.TypesInfo.ObjectOf(ctxIdent)
+		parentObj = dv.pass.TypesInfo.Uses[parentIdent]
+	)
+
+	if ctxObj == nil || parentObj == nil || ctxObj == parentObj || !isContextType(ctxObj.Type()) {
+		return
+	}
+
+	dv.contexts = append(dv.contexts, errgroupStackElement{
+		ctxObj:    ctxObj,
+		ctxName:   ctxIdent.Name,
+		depth:     len(stack),
+		parentObj: parentObj,
+		derive:    call,
+	})
+}
+
+// checkGoroutine reports references to the parents of the derived contexts in
+// scope within the code run by a new goroutine.
+func (dv *derivedVisitor) checkGoroutine(code ast.Node) {
+	if len(dv.contexts) == 0 {
+		return
+	}
+
+	ast.Inspect(code, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GoStmt:
+			// Visited on its own.
+			return false
+		case *ast.CallExpr:
+			if dv.isWaitGroupGo(n) {
+				return false
+			}
+		case *ast.Ident:
+			obj := dv.pass.TypesInfo.Uses[n]
+			if obj == nil {
+				return true
+			}
+
+			for i, elem := range slices.Backward(dv.contexts) {
+				if elem.parentObj != obj {
+					continue
+				}
+
+				derived := dv.mostDerived(i)
+
+				dv.report(RuleDerivedContext, n, []analysis.RelatedInformation{
+					relatedTo(elem.derive, "context %q is derived here", elem.ctxName),
+				}, "goroutine should probably not reference parent context %q, use the derived context %q",
+					n.Name, derived.ctxName)
+
+				break
+			}
+		}
+
+		return true
+	})
+}
+
+// mostDerived follows the chain of contexts derived from the i-th one, e.g.
+// ctx3 for ctx2 in "ctx2 := f(ctx1); ctx3 := f(ctx2)".
+func (dv *derivedVisitor) mostDerived(i int) errgroupStackElement {
+	elem := dv.contexts[i]
+
+	for _, next := range dv.contexts[i+1:] {
+		if next.parentObj == elem.ctxObj {
+			elem = next
+		}
+	}
+
+	return elem
+}
+
+// isWaitGroupGo reports whether the call is sync.WaitGroup.Go.
+func (dv *derivedVisitor) isWaitGroupGo(call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(dv.pass.TypesInfo, call).(*types.Func)
+
+	return ok && fn.FullName() == "(*sync.WaitGroup).Go"
+}
@@ -0,0 +1,148 @@
+package func_visitor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkDetachedRoot reports groups derived from context.Background(),
+// context.TODO() or a package-level context while the enclosing function has a
+// context at hand: a parameter or a field of its receiver. The group then
+// keeps running after the caller is cancelled.
+func (fv *funcVisitor) checkDetachedRoot(g *groupInfo, stack []ast.Node) {
+	if len(g.constructor.Args) == 0 || !constructorReturnsContext(g.constructor, fv.pass.TypesInfo) {
+		return
+	}
+
+	arg := ast.Unparen(g.constructor.Args[0])
+
+	root := fv.emptyContextCall(arg)
+	if root == "" {
+		root = fv.packageLevelContext(arg)
+	}
+
+	if root == "" {
+		return
+	}
+
+	available, decl := fv.availableContext(stack)
+	if decl == nil {
+		return
+	}
+
+	related := []analysis.RelatedInformation{
+		relatedTo(decl, "%s is declared here", available),
+	}
+
+	fv.report(RuleDetachedRoot, arg, related,
+		"errgroup %q is derived from %s although %s is available, it is not cancelled along with the caller",
+		g.groupName, root, available)
+}
+
+// emptyContextCall returns "context.Background()" or "context.TODO()" if the
+// expression is such a call.
+func (fv *funcVisitor) emptyContextCall(expr ast.Expr) string {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+
+	fn, ok := typeutil.Callee(fv.pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "context" || (fn.Name() != "Background" && fn.Name() != "TODO") {
+		return ""
+	}
+
+	return "context." + fn.Name() + "()"
+}
+
+// packageLevelContext describes the expression if it refers to a package-level
+// context variable.
+func (fv *funcVisitor) packageLevelContext(expr ast.Expr) string {
+	var ident *ast.Ident
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return ""
+	}
+
+	obj, ok := fv.pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() || !isContextType(obj.Type()) {
+		return ""
+	}
+
+	return fmt.Sprintf("package-level context %q", types.ExprString(expr))
+}
+
+// availableContext finds a context the enclosing functions could derive the
+// group from: a parameter of the innermost function having one, or a field of
+// the method receiver. It returns its description and declaration.
+func (fv *funcVisitor) availableContext(stack []ast.Node) (string, ast.Node) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var (
+			funcType *ast.FuncType
+			recv     *ast.FieldList
+		)
+
+		switch fn := stack[i].(type) {
+		case *ast.FuncLit:
+			funcType = fn.Type
+		case *ast.FuncDecl:
+			funcType, recv = fn.Type, fn.Recv
+		default:
+			continue
+		}
+
+		for _, field := range funcType.Params.List {
+			if !isContextType(fv.pass.TypesInfo.TypeOf(field.Type)) {
+				continue
+			}
+
+			for _, name := range field.Names {
+				if name.Name != "_" {
+					return fmt.Sprintf("parameter %q", name.Name), name
+				}
+			}
+		}
+
+		if recv != nil {
+			return fv.receiverContextField(recv)
+		}
+	}
+
+	return "", nil
+}
+
+// receiverContextField finds a context field of a struct receiver.
+func (fv *funcVisitor) receiverContextField(recv *ast.FieldList) (string, ast.Node) {
+	if len(recv.List) != 1 || len(recv.List[0].Names) != 1 || recv.List[0].Names[0].Name == "_" {
+		return "", nil
+	}
+
+	name := recv.List[0].Names[0]
+
+	typ := fv.pass.TypesInfo.TypeOf(recv.List[0].Type)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	st, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return "", nil
+	}
+
+	for field := range st.Fields() {
+		if isContextType(field.Type()) {
+			return fmt.Sprintf("receiver field %q", name.Name+"."+field.Name()), name
+		}
+	}
+
+	return "", nil
+}
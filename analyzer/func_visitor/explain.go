@@ -0,0 +1,83 @@
+package func_visitor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// explainer prints how the visitor treated the errgroup callbacks covering a
+// single source line. A nil explainer explains nothing.
+type explainer struct {
+	file string
+	line int
+	out  io.Writer
+}
+
+func newExplainer(cfg Config) *explainer {
+	if cfg.explainFile == "" {
+		return nil
+	}
+
+	return &explainer{
+		file: cfg.explainFile,
+		line: cfg.explainLine,
+		out:  cfg.ExplainOutput,
+	}
+}
+
+// covers reports whether the node spans the explained line.
+func (e *explainer) covers(fset *token.FileSet, node ast.Node) bool {
+	if e == nil {
+		return false
+	}
+
+	start, end := fset.Position(node.Pos()), fset.Position(node.End())
+
+	return e.matchesFile(start.Filename) && start.Line <= e.line && e.line <= end.Line
+}
+
+// coversPos reports whether the position is on the explained line.
+func (e *explainer) coversPos(fset *token.FileSet, pos token.Pos) bool {
+	if e == nil {
+		return false
+	}
+
+	position := fset.Position(pos)
+
+	return e.matchesFile(position.Filename) && position.Line == e.line
+}
+
+func (e *explainer) matchesFile(filename string) bool {
+	filename = filepath.ToSlash(filename)
+
+	return filename == e.file || strings.HasSuffix(filename, "/"+e.file)
+}
+
+func (e *explainer) header(fset *token.FileSet, funcLit *ast.FuncLit, elem *errgroupStackElement, stack errgroupStack) {
+	fmt.Fprintf(e.out, "%s:%d: errgroup callback at %s\n", e.file, e.line, fset.Position(funcLit.Pos()))
+
+	e.printf("errgroup stack (innermost last):")
+	for i, frame := range stack {
+		e.printf("  #%d %s", i, describeStackElement(fset, &frame))
+	}
+
+	e.printf("matched %s", describeStackElement(fset, elem))
+}
+
+func (e *explainer) printf(format string, args ...any) {
+	fmt.Fprintf(e.out, "\t"+format+"\n", args...)
+}
+
+func describeStackElement(fset *token.FileSet, elem *errgroupStackElement) string {
+	desc := fmt.Sprintf("group %q declared at %s", elem.groupObj.Name(), fset.Position(elem.groupObj.Pos()))
+
+	if elem.ctxObj == nil {
+		return desc + ", no derived context"
+	}
+
+	return desc + fmt.Sprintf(", derived context %q declared at %s", elem.ctxName, fset.Position(elem.ctxObj.Pos()))
+}
@@ -0,0 +1,178 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// FactoryFact marks a function returning a callback that references
+// package-level contexts, e.g. "func makeWorker(item int) func() error"
+// returning "func() error { return doSmth(appCtx) }". Passing its result to
+// Go or TryGo makes the callback use those contexts.
+type FactoryFact struct {
+	// Contexts are the names of the package-level contexts.
+	Contexts []string
+}
+
+func (*FactoryFact) AFact() {}
+
+func (f *FactoryFact) String() string {
+	return "factory of callbacks referencing " + strings.Join(f.Contexts, ", ")
+}
+
+// ExportFactoryFacts exports a FactoryFact for every function of the package
+// that returns a callback referencing a package-level context. It must be
+// called before the pass is visited, so that calls within the package see the
+// facts as well.
+func (fv *funcVisitor) ExportFactoryFacts() {
+	for _, file := range fv.pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil || !returnsFunc(funcDecl.Type) {
+				continue
+			}
+
+			fn, ok := fv.pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+
+			if contexts := fv.returnedPackageContexts(funcDecl.Body); len(contexts) > 0 {
+				fv.pass.ExportObjectFact(fn, &FactoryFact{Contexts: contexts})
+			}
+		}
+	}
+}
+
+// returnedPackageContexts returns the names of the package-level contexts the
+// closures returned from the function body reference, sorted.
+func (fv *funcVisitor) returnedPackageContexts(body *ast.BlockStmt) []string {
+	seen := make(map[string]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok {
+			// Returns of nested closures belong to them.
+			_, isFuncLit := n.(*ast.FuncLit)
+			return !isFuncLit
+		}
+
+		for _, result := range ret.Results {
+			var funcLit *ast.FuncLit
+
+			switch result := ast.Unparen(result).(type) {
+			case *ast.FuncLit:
+				funcLit = result
+			case *ast.Ident:
+				funcLit = fv.localClosure(result)
+			}
+
+			if funcLit == nil {
+				continue
+			}
+
+			ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+				ident, ok := n.(*ast.Ident)
+				if !ok {
+					return true
+				}
+
+				obj, ok := fv.pass.TypesInfo.Uses[ident].(*types.Var)
+				if ok && isPackageLevel(obj) && isContextType(obj.Type()) {
+					seen[obj.Name()] = true
+				}
+
+				return true
+			})
+		}
+
+		return false
+	})
+
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// checkCallbackFactory checks a call passed to Go or TryGo, as in
+// "eg.Go(makeWorker(ctx, item))". It reports the factory if the callback it
+// returns references package-level contexts, and returns the outer contexts
+// passed to it, for the caller to report.
+func (fv *funcVisitor) checkCallbackFactory(
+	factory *ast.CallExpr,
+	elem *errgroupStackElement,
+	derivedName string,
+	kind string,
+) []*ast.Ident {
+	var outerArgs []*ast.Ident
+
+	for _, arg := range factory.Args {
+		ast.Inspect(arg, func(n ast.Node) bool {
+			ident, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+
+			obj, ok := fv.pass.TypesInfo.Uses[ident].(*types.Var)
+			if !ok || !isContextType(obj.Type()) {
+				return true
+			}
+
+			if outer, _ := fv.classifyContextRef(ident, obj, factory, elem); outer {
+				outerArgs = append(outerArgs, ident)
+			}
+
+			return true
+		})
+	}
+
+	fn := typeutil.StaticCallee(fv.pass.TypesInfo, factory)
+	if fn == nil {
+		return outerArgs
+	}
+
+	var fact FactoryFact
+	if !fv.pass.ImportObjectFact(fn, &fact) {
+		return outerArgs
+	}
+
+	related := relatedTo(elem.info.constructor, "errgroup %q is created here", elem.groupObj.Name())
+
+	message := fv.outerContextMessage(MessageData{
+		OuterContext:   fact.Contexts[0],
+		OuterContexts:  fact.Contexts,
+		DerivedContext: derivedName,
+		Group:          elem.groupObj.Name(),
+		Rule:           RuleOuterContext,
+		CallbackKind:   kind,
+	})
+
+	if fv.report(RuleOuterContext, factory.Fun, []analysis.RelatedInformation{related}, "%s", message) {
+		elem.info.flagged = true
+	}
+
+	return outerArgs
+}
+
+// returnsFunc reports whether the function returns a function.
+func returnsFunc(funcType *ast.FuncType) bool {
+	if funcType.Results == nil {
+		return false
+	}
+
+	for _, field := range funcType.Results.List {
+		if _, ok := ast.Unparen(field.Type).(*ast.FuncType); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isPackageLevel(obj types.Object) bool {
+	return obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope()
+}
@@ -0,0 +1,107 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkUnboundedFanOut reports Go calls in loops on groups without a limit:
+// every iteration starts a goroutine, as many as the collection has items.
+// Loops of a constant size are only reported above the configured threshold.
+func (fv *funcVisitor) checkUnboundedFanOut(g *groupInfo) {
+	for _, goCall := range g.goCalls {
+		loop := fv.enclosingLoop(goCall.call, g.constructor)
+		if loop == nil || fv.limitedBefore(g, goCall.call) {
+			continue
+		}
+
+		if n, ok := fv.loopSize(loop); ok && (fv.cfg.FanOutThreshold <= 0 || n <= fv.cfg.FanOutThreshold) {
+			continue
+		}
+
+		fv.report(RuleUnboundedFanOut, goCall.call.Fun, []analysis.RelatedInformation{
+			relatedTo(loopKeyword(loop), "the loop starts here"),
+		}, "errgroup %q starts a goroutine per loop iteration without a limit, call SetLimit before the loop",
+			g.groupName)
+	}
+}
+
+// enclosingLoop returns the innermost loop containing the call within its
+// function, unless the loop contains the group constructor as well: such a
+// group is created anew on every iteration.
+func (fv *funcVisitor) enclosingLoop(call *ast.CallExpr, constructor *ast.CallExpr) ast.Node {
+	for _, n := range fv.pathTo(call.Pos()) {
+		switch n.(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return nil
+		case *ast.ForStmt, *ast.RangeStmt:
+			if n.Pos() <= constructor.Pos() && constructor.End() <= n.End() {
+				return nil
+			}
+
+			return n
+		}
+	}
+
+	return nil
+}
+
+// limitedBefore reports whether SetLimit is called on the group before the
+// call.
+func (fv *funcVisitor) limitedBefore(g *groupInfo, call *ast.CallExpr) bool {
+	for _, setLimit := range g.setLimitCalls {
+		if !setLimit.deferred && setLimit.call.Pos() < call.Pos() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loopSize returns the number of iterations of a loop if it is known
+// statically: a range over an array or a constant integer, or a three-clause
+// loop comparing against a constant.
+func (fv *funcVisitor) loopSize(loop ast.Node) (int64, bool) {
+	switch loop := loop.(type) {
+	case *ast.RangeStmt:
+		typ := fv.pass.TypesInfo.TypeOf(loop.X)
+		if typ == nil {
+			return 0, false
+		}
+
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+
+		if array, ok := typ.Underlying().(*types.Array); ok {
+			return array.Len(), true
+		}
+
+		return fv.constantInt(loop.X)
+	case *ast.ForStmt:
+		cond, ok := loop.Cond.(*ast.BinaryExpr)
+		if !ok {
+			return 0, false
+		}
+
+		switch cond.Op {
+		case token.LSS, token.LEQ, token.NEQ:
+			return fv.constantInt(cond.Y)
+		}
+	}
+
+	return 0, false
+}
+
+func (fv *funcVisitor) constantInt(expr ast.Expr) (int64, bool) {
+	tv, ok := fv.pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return 0, false
+	}
+
+	return constant.Int64Val(tv.Value)
+}
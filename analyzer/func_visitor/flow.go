@@ -0,0 +1,195 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/cfg"
+)
+
+// funcCFG returns the control-flow graph of a function declaration or literal.
+func (fv *funcVisitor) funcCFG(fn ast.Node) *cfg.CFG {
+	cfgs, _ := fv.pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+	if cfgs == nil {
+		return nil
+	}
+
+	switch fn := fn.(type) {
+	case *ast.FuncDecl:
+		return cfgs.FuncDecl(fn)
+	case *ast.FuncLit:
+		return cfgs.FuncLit(fn)
+	}
+
+	return nil
+}
+
+// locate finds the CFG node containing n.
+func locate(g *cfg.CFG, n ast.Node) (*cfg.Block, int, bool) {
+	for _, block := range g.Blocks {
+		for i, node := range block.Nodes {
+			if node.Pos() <= n.Pos() && n.End() <= node.End() {
+				return block, i, true
+			}
+		}
+	}
+
+	return nil, 0, false
+}
+
+// mayFollow reports whether "to" may execute after "from" along a path in g
+// that does not go through a node for which stop returns true.
+func mayFollow(g *cfg.CFG, from, to ast.Node, stop func(ast.Node) bool) bool {
+	if g == nil {
+		return false
+	}
+
+	toBlock, toIndex, ok := locate(g, to)
+	if !ok {
+		return false
+	}
+
+	return search(g, from, stop,
+		func(block *cfg.Block, i int) bool { return block == toBlock && i == toIndex },
+		func(*cfg.Block) bool { return false },
+	)
+}
+
+// mayReturnAfter reports whether the function may return after "from" along
+// a path in g that does not go through a node for which stop returns true.
+// Paths ending in a call that never returns, such as panic, do not count.
+func mayReturnAfter(g *cfg.CFG, from ast.Node, stop func(ast.Node) bool) bool {
+	if g == nil {
+		return false
+	}
+
+	// The builder starts an unreachable block right after every call that
+	// never returns.
+	noReturn := make(map[ast.Stmt]bool)
+	for _, block := range g.Blocks {
+		if stmt, ok := block.Stmt.(*ast.ExprStmt); ok && block.Kind == cfg.KindUnreachable {
+			noReturn[stmt] = true
+		}
+	}
+
+	return search(g, from, stop,
+		func(*cfg.Block, int) bool { return false },
+		func(block *cfg.Block) bool {
+			if len(block.Nodes) == 0 {
+				return true
+			}
+
+			last, _ := block.Nodes[len(block.Nodes)-1].(ast.Stmt)
+
+			return !noReturn[last]
+		},
+	)
+}
+
+// search walks g forward from the node following "from". It succeeds as soon
+// as match accepts a node or atExit accepts a block without successors that
+// the walk went all the way through. Paths are cut at nodes accepted by stop.
+func search(
+	g *cfg.CFG,
+	from ast.Node,
+	stop func(ast.Node) bool,
+	match func(*cfg.Block, int) bool,
+	atExit func(*cfg.Block) bool,
+) bool {
+	fromBlock, fromIndex, ok := locate(g, from)
+	if !ok {
+		return false
+	}
+
+	// scan walks the nodes of the block from the index on, telling whether
+	// the search succeeded and whether the walk may continue to the
+	// successors.
+	scan := func(block *cfg.Block, index int) (found, pass bool) {
+		for i := index; i < len(block.Nodes); i++ {
+			if match(block, i) {
+				return true, false
+			}
+
+			if stop(block.Nodes[i]) {
+				return false, false
+			}
+		}
+
+		if len(block.Succs) == 0 {
+			return atExit(block), false
+		}
+
+		return false, true
+	}
+
+	found, pass := scan(fromBlock, fromIndex+1)
+	if found || !pass {
+		return found
+	}
+
+	visited := make(map[*cfg.Block]bool)
+	queue := append([]*cfg.Block(nil), fromBlock.Succs...)
+
+	for len(queue) > 0 {
+		block := queue[0]
+		queue = queue[1:]
+
+		if visited[block] {
+			continue
+		}
+		visited[block] = true
+
+		found, pass := scan(block, 0)
+		if found {
+			return true
+		}
+
+		if pass {
+			queue = append(queue, block.Succs...)
+		}
+	}
+
+	return false
+}
+
+// assignsObject returns a predicate telling whether a CFG node (re)assigns
+// the object.
+func assignsObject(obj types.Object, typesInfo *types.Info) func(ast.Node) bool {
+	return func(n ast.Node) bool {
+		var idents []ast.Expr
+
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			idents = n.Lhs
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				idents = append(idents, name)
+			}
+		default:
+			return false
+		}
+
+		for _, e := range idents {
+			if ident, ok := e.(*ast.Ident); ok && typesInfo.ObjectOf(ident) == obj {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// containsCall returns a predicate telling whether a CFG node contains one of
+// the calls, not counting deferred ones.
+func containsCall(calls []groupCall) func(ast.Node) bool {
+	return func(n ast.Node) bool {
+		for _, c := range calls {
+			if !c.deferred && n.Pos() <= c.call.Pos() && c.call.End() <= n.End() {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -1,13 +1,16 @@
 package func_visitor
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
 	"log"
 	"slices"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
 )
 
 type funcVisitor struct {
@@ -17,6 +20,9 @@ type funcVisitor struct {
 	nolintLines map[CommentPosition]struct{}
 
 	errgroupStack errgroupStack
+	groups        []*groupInfo
+
+	explainer *explainer
 }
 
 func New(
@@ -32,6 +38,7 @@ func New(
 		cfg:         cfg,
 		pass:        pass,
 		nolintLines: nolintLines,
+		explainer:   newExplainer(cfg),
 	}
 }
 
@@ -44,27 +51,87 @@ func (fv *funcVisitor) Visit(node ast.Node, push bool, stack []ast.Node) bool {
 
 	switch n := node.(type) {
 	case *ast.AssignStmt:
-		fv.visitAssignStmt(n, len(stack))
+		fv.visitAssignStmt(n, stack)
 	case *ast.DeclStmt:
-		fv.visitDeclStmt(n, len(stack))
+		fv.visitDeclStmt(n, stack)
 	case *ast.CallExpr:
-		fv.visitCallExpr(n)
+		fv.visitCallExpr(n, stack)
 	}
 
 	return true
 }
 
-func (fv *funcVisitor) visitCallExpr(callExpr *ast.CallExpr) {
+// CheckGroups runs the rules that need to know about every call on a group.
+// It must be called once the whole pass has been visited.
+func (fv *funcVisitor) CheckGroups() {
+	for _, g := range fv.groups {
+		if fv.cfg.RuleEnabled(RuleSetLimit) {
+			fv.checkSetLimit(g)
+		}
+
+		if fv.cfg.RuleEnabled(RuleTryGoResult) {
+			fv.checkTryGoResults(g)
+		}
+
+		if fv.cfg.RuleEnabled(RuleMissingWait) {
+			fv.checkMissingWait(g)
+		}
+
+		if fv.cfg.RuleEnabled(RuleUncheckedWait) {
+			fv.checkWaitErrors(g)
+		}
+
+		if fv.cfg.RuleEnabled(RuleWaitInCallback) {
+			fv.checkWaitInCallbacks(g)
+		}
+
+		if fv.cfg.RuleEnabled(RuleGoAfterWait) {
+			fv.checkGoAfterWait(g)
+		}
+
+		if fv.cfg.RuleEnabled(RuleUnusedContext) {
+			fv.checkUnusedContext(g)
+		}
+
+		if fv.cfg.RuleEnabled(RuleShadowContext) {
+			fv.checkShadowContext(g)
+		}
+
+		if fv.cfg.RuleEnabled(RuleUnboundedFanOut) {
+			fv.checkUnboundedFanOut(g)
+		}
+	}
+}
+
+// Groups returns a report for every errgroup the visitor has seen so far, in
+// the order of their construction.
+func (fv *funcVisitor) Groups() []GroupReport {
+	reports := make([]GroupReport, 0, len(fv.groups))
+	for _, g := range fv.groups {
+		reports = append(reports, g.report(fv.pass.Fset, fv.pass.TypesInfo))
+	}
+
+	return reports
+}
+
+func (fv *funcVisitor) visitCallExpr(callExpr *ast.CallExpr, stack []ast.Node) {
 	if len(fv.errgroupStack) == 0 {
 		return
 	}
 
-	errgroupClosure := tryGetErrgroupClosureFromCallExpr(callExpr, fv.pass.TypesInfo, fv.cfg)
-	if errgroupClosure == nil {
+	if spawner, closure := fv.spawnerClosure(callExpr); closure != nil {
+		if fv.cfg.RuleEnabled(RuleOuterContext) {
+			fv.checkSpawnerClosure(closure, spawner, stack)
+		}
+
+		return
+	}
+
+	sel, method := errgroupMethodFromCallExpr(callExpr, fv.pass.TypesInfo, fv.cfg)
+	if sel == nil {
 		return
 	}
 
-	sel := callExpr.Fun.(*ast.SelectorExpr) // safe: callIsErrgroupGoOrTryGo verified this
 	xIdent, ok := sel.X.(*ast.Ident)
 	if !ok {
 		return
@@ -80,10 +147,45 @@ func (fv *funcVisitor) visitCallExpr(callExpr *ast.CallExpr) {
 		return
 	}
 
-	fv.checkClosureForContexts(errgroupClosure, elem)
+	elem.info.record(method, newGroupCall(callExpr, stack))
+
+	errgroupClosure := tryGetErrgroupClosureFromCallExpr(callExpr, fv.pass.TypesInfo, fv.cfg)
+	if errgroupClosure == nil {
+		if fv.cfg.RuleEnabled(RuleOuterContext) {
+			fv.checkCallbackValue(callExpr, elem, method)
+		}
+
+		return
+	}
+
+	elem.info.callbacks = append(elem.info.callbacks, groupCallback{call: callExpr, funcLit: errgroupClosure})
+
+	if fv.cfg.RuleEnabled(RuleOuterContext) {
+		fv.checkClosureForContexts(errgroupClosure, errgroupClosure, elem, method, errgroupClosure, elem)
+	}
+
+	if fv.cfg.RuleEnabled(RuleBlockingChannel) {
+		fv.checkBlockingChannels(errgroupClosure, elem)
+	}
+
+	if fv.cfg.RuleEnabled(RuleUncancellableLoop) {
+		fv.checkUncancellableLoops(errgroupClosure, elem)
+	}
+
+	if fv.cfg.RuleEnabled(RuleUntrackedGo) {
+		fv.checkUntrackedGoroutines(errgroupClosure, elem)
+	}
+
+	if fv.cfg.RuleEnabled(RuleSwallowedError) {
+		fv.checkSwallowedErrors(errgroupClosure, elem)
+	}
 }
 
-func (fv *funcVisitor) visitAssignStmt(assignStmt *ast.AssignStmt, depth int) {
+func (fv *funcVisitor) visitAssignStmt(assignStmt *ast.AssignStmt, stack []ast.Node) {
+	if fv.cfg.RuleEnabled(RuleHandRolledGroup) {
+		fv.checkHandRolledGroup(assignStmt, stack)
+	}
+
 	if len(assignStmt.Rhs) != 1 {
 		return
 	}
@@ -98,7 +200,7 @@ func (fv *funcVisitor) visitAssignStmt(assignStmt *ast.AssignStmt, depth int) {
 	}
 
 	newErrgroupElement := errgroupStackElement{
-		depth: depth,
+		depth: len(stack),
 	}
 
 	var idents []*ast.Ident
@@ -112,11 +214,11 @@ func (fv *funcVisitor) visitAssignStmt(assignStmt *ast.AssignStmt, depth int) {
 	fillStackElemFromIdents(&newErrgroupElement, idents, fv.pass.TypesInfo, fv.cfg)
 
 	if newErrgroupElement.groupObj != nil {
-		fv.errgroupStack = append(fv.errgroupStack, newErrgroupElement)
+		fv.pushGroup(newErrgroupElement, callExpr, stack)
 	}
 }
 
-func (fv *funcVisitor) visitDeclStmt(declStmt *ast.DeclStmt, depth int) {
+func (fv *funcVisitor) visitDeclStmt(declStmt *ast.DeclStmt, stack []ast.Node) {
 	genDecl, ok := declStmt.Decl.(*ast.GenDecl)
 	if !ok || genDecl == nil {
 		return
@@ -127,7 +229,7 @@ func (fv *funcVisitor) visitDeclStmt(declStmt *ast.DeclStmt, depth int) {
 	}
 
 	newErrgroupElement := errgroupStackElement{
-		depth: depth,
+		depth: len(stack),
 	}
 
 	for _, spec := range genDecl.Specs {
@@ -152,13 +254,38 @@ func (fv *funcVisitor) visitDeclStmt(declStmt *ast.DeclStmt, depth int) {
 		fillStackElemFromIdents(&newErrgroupElement, valSpec.Names, fv.pass.TypesInfo, fv.cfg)
 
 		if newErrgroupElement.groupObj != nil {
-			fv.errgroupStack = append(fv.errgroupStack, newErrgroupElement)
+			fv.pushGroup(newErrgroupElement, callExpr, stack)
 
 			return
 		}
 	}
 }
 
+func (fv *funcVisitor) pushGroup(elem errgroupStackElement, constructor *ast.CallExpr, stack []ast.Node) {
+	elem.info = &groupInfo{
+		constructor: constructor,
+		decl:        stack[len(stack)-1],
+		fn:          enclosingFunc(stack),
+		groupObj:    elem.groupObj,
+		groupName:   elem.groupObj.Name(),
+		ctxObj:      elem.ctxObj,
+		ctxName:     elem.ctxName,
+	}
+
+	if elem.ctxName == "" && constructorReturnsContext(constructor, fv.pass.TypesInfo) {
+		elem.info.ctxName = "_"
+	}
+
+	nested := fv.cfg.RuleEnabled(RuleNestedGroup) && fv.checkNestedGroup(elem.info, stack)
+
+	if !nested && fv.cfg.RuleEnabled(RuleDetachedRoot) {
+		fv.checkDetachedRoot(elem.info, stack)
+	}
+
+	fv.groups = append(fv.groups, elem.info)
+	fv.errgroupStack = append(fv.errgroupStack, elem)
+}
+
 func fillStackElemFromIdents(elem *errgroupStackElement, idents []*ast.Ident, typesInfo *types.Info, cfg Config) {
 	for _, ident := range idents {
 		if ident.Name == "_" {
@@ -270,18 +397,51 @@ func errgroupPkgPathIsEnabled(cfg Config, packagePath string) bool {
 	return slices.Contains(cfg.ErrgroupPackagePaths, packagePath)
 }
 
-func (fv *funcVisitor) checkClosureForContexts(funcLit *ast.FuncLit, elem *errgroupStackElement) {
+// checkClosureForContexts reports outer contexts referenced by the function
+// literal. Contexts declared within scope are not outer. Groups created within
+// the function literal are nested in enclosingCallback, the innermost callback
+// of the enclosing group, if any.
+func (fv *funcVisitor) checkClosureForContexts(
+	funcLit, scope *ast.FuncLit,
+	elem *errgroupStackElement,
+	kind string,
+	enclosingCallback *ast.FuncLit,
+	enclosing *errgroupStackElement,
+) {
+	explain := fv.explainer.covers(fv.pass.Fset, funcLit)
+	if explain {
+		fv.explainer.header(fv.pass.Fset, funcLit, elem, fv.errgroupStack)
+	}
+
 	if elem.ctxObj == nil {
+		if explain {
+			fv.explainer.printf("group %q has no derived context, the callback is not checked", elem.groupObj.Name())
+		}
+
 		return
 	}
 
-	closureStart := funcLit.Pos()
-	closureEnd := funcLit.End()
-
-	// Identify func lits that are arguments to errgroup Go/TryGo calls,
-	// these will be independently analyzed by the inspector, so we skip them
+	// Identify func lits that are arguments to errgroup Go/TryGo calls or to
+	// secondary spawners, these will be independently analyzed by the
+	// inspector, so we skip them.
+	// Parents of nested errgroups are skipped too, they have their own rule.
 	skipFuncLits := make(map[*ast.FuncLit]struct{})
-	ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+	nestedParents := make(map[*ast.Ident]struct{})
+	fieldKeys := make(map[*ast.Ident]struct{})
+	ast.PreorderStack(funcLit.Body, nil, func(n ast.Node, stack []ast.Node) bool {
+		if lit, ok := n.(*ast.CompositeLit); ok {
+			// Field names in keyed struct literals are not references.
+			if _, ok := fv.pass.TypesInfo.TypeOf(lit).Underlying().(*types.Struct); ok {
+				for _, elt := range lit.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						if key, ok := kv.Key.(*ast.Ident); ok {
+							fieldKeys[key] = struct{}{}
+						}
+					}
+				}
+			}
+		}
+
 		call, ok := n.(*ast.CallExpr)
 		if !ok {
 			return true
@@ -291,6 +451,14 @@ func (fv *funcVisitor) checkClosureForContexts(funcLit *ast.FuncLit, elem *errgr
 			skipFuncLits[innerErrgroupClosure] = struct{}{}
 		}
 
+		if _, spawnerClosure := fv.spawnerClosure(call); spawnerClosure != nil {
+			skipFuncLits[spawnerClosure] = struct{}{}
+		}
+
+		if parentIdent := fv.nestedGroupParent(call, parent(stack), enclosingCallback, enclosing); parentIdent != nil && fv.cfg.RuleEnabled(RuleNestedGroup) {
+			nestedParents[parentIdent] = struct{}{}
+		}
+
 		return true
 	})
 
@@ -299,19 +467,46 @@ func (fv *funcVisitor) checkClosureForContexts(funcLit *ast.FuncLit, elem *errgr
 		derivedName = "<errgroup context>"
 	}
 
+	var (
+		outerRefs []*ast.Ident
+		captures  []capture
+	)
+
 	// Check all identifiers, skipping nested errgroup callback bodies
 	ast.Inspect(funcLit.Body, func(n ast.Node) bool {
 		if fl, ok := n.(*ast.FuncLit); ok {
 			if _, skip := skipFuncLits[fl]; skip {
+				if explain && fv.explainer.covers(fv.pass.Fset, fl) {
+					fv.explainer.printf("nested errgroup callback at %s is analyzed separately",
+						fv.pass.Fset.Position(fl.Pos()))
+				}
+
 				return false
 			}
 		}
 
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			for _, c := range fv.structCaptures(sel, scope, elem) {
+				if explain && fv.explainer.coversPos(fv.pass.Fset, sel.Pos()) {
+					fv.explainer.printf("%s %q: outer: calls a method of a value carrying %q stored at %s",
+						fv.pass.Fset.Position(sel.Pos()), types.ExprString(sel), c.capture.Name, fv.pass.Fset.Position(c.capture.Pos()))
+				}
+
+				captures = append(captures, c)
+			}
+
+			return true
+		}
+
 		ident, ok := n.(*ast.Ident)
 		if !ok {
 			return true
 		}
 
+		if _, ok := fieldKeys[ident]; ok {
+			return true
+		}
+
 		obj := fv.pass.TypesInfo.Uses[ident]
 		if obj == nil {
 			return true
@@ -321,65 +516,272 @@ func (fv *funcVisitor) checkClosureForContexts(funcLit *ast.FuncLit, elem *errgr
 			return true
 		}
 
-		if !isContextType(obj.Type()) {
+		if helper := fv.localClosure(ident); helper != nil && !contains(scope, helper) {
+			for _, c := range fv.helperCaptures(ident, helper, elem, nil) {
+				if explain && fv.explainer.coversPos(fv.pass.Fset, ident.Pos()) {
+					fv.explainer.printf("%s %q: outer: calls a closure capturing %q at %s",
+						fv.pass.Fset.Position(ident.Pos()), ident.Name, c.capture.Name, fv.pass.Fset.Position(c.capture.Pos()))
+				}
+
+				captures = append(captures, c)
+			}
+
 			return true
 		}
 
-		// Allow the errgroup-derived context itself
-		if elem.ctxObj != nil && obj == elem.ctxObj {
+		if !isContextType(obj.Type()) {
 			return true
 		}
 
-		// Allow contexts defined within the closure body
-		if obj.Pos() >= closureStart && obj.Pos() < closureEnd {
-			return true
+		outer, reason := fv.classifyContextRef(ident, obj, scope, elem)
+		if _, ok := nestedParents[ident]; ok && outer {
+			outer, reason = false, "skipped: the parent of a nested errgroup, see the nested-group rule"
 		}
 
-		if positionIsNoLint(ident.Pos(), fv.pass.Fset, fv.nolintLines) {
-			return true
+		if explain && fv.explainer.coversPos(fv.pass.Fset, ident.Pos()) {
+			fv.explainer.printf("%s %q: %s", fv.pass.Fset.Position(ident.Pos()), ident.Name, reason)
 		}
 
-		fv.pass.Reportf(ident.Pos(),
-			"errgroup callback should probably not reference outer context %q, use the errgroup-derived context %q",
-			ident.Name, derivedName)
+		if outer {
+			outerRefs = append(outerRefs, ident)
+		}
 
 		return true
 	})
+
+	if fv.cfg.AggregateByCallback {
+		for _, c := range captures {
+			outerRefs = append(outerRefs, c.capture)
+		}
+
+		fv.reportOuterContextsOfCallback(funcLit.Type, elem, outerRefs, derivedName, kind)
+
+		return
+	}
+
+	for _, ident := range outerRefs {
+		fv.reportOuterContext(ident, elem, derivedName, kind)
+	}
+
+	for _, c := range fv.uniqueCaptures(captures) {
+		fv.reportCapturedOuterContext(c, elem, derivedName, kind)
+	}
+}
+
+func (fv *funcVisitor) reportOuterContext(ident *ast.Ident, elem *errgroupStackElement, derivedName, kind string) {
+	related := []analysis.RelatedInformation{
+		relatedTo(elem.info.constructor, "errgroup %q is created here", elem.groupObj.Name()),
+	}
+	if decl := fv.relatedToDeclaration(ident); decl != nil {
+		related = append(related, *decl)
+	}
+
+	message := fv.outerContextMessage(MessageData{
+		OuterContext:   ident.Name,
+		OuterContexts:  []string{ident.Name},
+		DerivedContext: derivedName,
+		Group:          elem.groupObj.Name(),
+		Rule:           RuleOuterContext,
+		CallbackKind:   kind,
+	})
+
+	if fv.report(RuleOuterContext, ident, related, "%s", message) {
+		elem.info.flagged = true
+	}
+}
+
+// reportOuterContextsOfCallback emits a single diagnostic at the callback,
+// listing every distinct outer context it references.
+func (fv *funcVisitor) reportOuterContextsOfCallback(
+	callback ast.Node,
+	elem *errgroupStackElement,
+	outerRefs []*ast.Ident,
+	derivedName string,
+	kind string,
+) {
+	if len(outerRefs) == 0 {
+		return
+	}
+
+	var (
+		names   []string
+		seen    = make(map[types.Object]struct{})
+		related = []analysis.RelatedInformation{
+			relatedTo(elem.info.constructor, "errgroup %q is created here", elem.groupObj.Name()),
+		}
+	)
+
+	for _, ident := range outerRefs {
+		related = append(related, relatedTo(ident, "outer context %q is referenced here", ident.Name))
+
+		obj := fv.pass.TypesInfo.Uses[ident]
+		if _, ok := seen[obj]; ok {
+			continue
+		}
+
+		seen[obj] = struct{}{}
+		names = append(names, ident.Name)
+	}
+
+	message := fv.outerContextMessage(MessageData{
+		OuterContext:   names[0],
+		OuterContexts:  names,
+		DerivedContext: derivedName,
+		Group:          elem.groupObj.Name(),
+		Rule:           RuleOuterContext,
+		CallbackKind:   kind,
+	})
+
+	if fv.report(RuleOuterContext, callback, related, "%s", message) {
+		elem.info.flagged = true
+	}
+}
+
+// relatedToDeclaration points at the declaration of the object the identifier
+// refers to, if it is known.
+func (fv *funcVisitor) relatedToDeclaration(ident *ast.Ident) *analysis.RelatedInformation {
+	obj := fv.pass.TypesInfo.Uses[ident]
+	if obj == nil || !obj.Pos().IsValid() {
+		return nil
+	}
+
+	return &analysis.RelatedInformation{
+		Pos:     obj.Pos(),
+		End:     obj.Pos() + token.Pos(len(obj.Name())),
+		Message: fmt.Sprintf("outer context %q is declared here", obj.Name()),
+	}
+}
+
+// classifyContextRef decides whether a reference to a context variable within
+// an errgroup callback refers to an outer context, and explains why.
+func (fv *funcVisitor) classifyContextRef(
+	ident *ast.Ident,
+	obj types.Object,
+	callback ast.Node,
+	elem *errgroupStackElement,
+) (bool, string) {
+	// Allow the errgroup-derived context itself
+	if obj == elem.ctxObj {
+		return false, "allowed: the errgroup-derived context"
+	}
+
+	// Allow contexts defined within the closure body
+	if obj.Pos() >= callback.Pos() && obj.Pos() < callback.End() {
+		return false, fmt.Sprintf("allowed: declared inside the callback at %s", fv.pass.Fset.Position(obj.Pos()))
+	}
+
+	if positionIsNoLint(ident.Pos(), fv.pass.Fset, fv.nolintLines) {
+		return false, "allowed: suppressed by a nolint comment"
+	}
+
+	return true, fmt.Sprintf("outer: declared at %s, outside the callback", fv.pass.Fset.Position(obj.Pos()))
 }
 
 func tryGetErrgroupClosureFromCallExpr(callExpr *ast.CallExpr, typesInfo *types.Info, cfg Config) *ast.FuncLit {
-	sel, ok := callExpr.Fun.(*ast.SelectorExpr)
-	if !ok {
+	sel, method := errgroupMethodFromCallExpr(callExpr, typesInfo, cfg)
+	if sel == nil {
 		return nil
 	}
 
-	if sel.Sel.Name != "Go" && sel.Sel.Name != "TryGo" {
+	if method != methodGo && method != methodTryGo {
 		return nil
 	}
 
+	if len(callExpr.Args) != 1 {
+		return nil
+	}
+
+	funcLit, _ := callExpr.Args[0].(*ast.FuncLit)
+
+	return funcLit
+}
+
+// checkSpawnerClosure checks a callback passed to a secondary spawner against
+// the innermost errgroup in scope. If the spawner is called from a callback of
+// that very group, contexts declared within the callback are allowed as well.
+func (fv *funcVisitor) checkSpawnerClosure(closure *ast.FuncLit, spawner string, stack []ast.Node) {
+	elem := fv.errgroupStack[len(fv.errgroupStack)-1]
+
+	callback, enclosing := fv.enclosingCallback(stack)
+
+	scope := closure
+	if enclosing != nil && enclosing.groupObj == elem.groupObj {
+		scope = callback
+	}
+
+	fv.checkClosureForContexts(closure, scope, &elem, spawner, callback, enclosing)
+}
+
+// spawnerClosure returns the name of the secondary spawner the call is made
+// to, without the package path, and the function literal passed to it as the
+// callback.
+func (fv *funcVisitor) spawnerClosure(callExpr *ast.CallExpr) (string, *ast.FuncLit) {
+	fn, ok := typeutil.Callee(fv.pass.TypesInfo, callExpr).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return "", nil
+	}
+
+	name := qualifiedFuncName(fn)
+
+	for _, spawner := range fv.cfg.Spawners {
+		if spawner.Func != name || spawner.Arg >= len(callExpr.Args) {
+			continue
+		}
+
+		if funcLit, ok := callExpr.Args[spawner.Arg].(*ast.FuncLit); ok {
+			return strings.TrimPrefix(name, fn.Pkg().Path()+"."), funcLit
+		}
+	}
+
+	return "", nil
+}
+
+// errgroupMethodFromCallExpr returns the selector and the method name if the
+// call is a method call on a type from one of the enabled errgroup packages.
+func errgroupMethodFromCallExpr(callExpr *ast.CallExpr, typesInfo *types.Info, cfg Config) (*ast.SelectorExpr, string) {
+	sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, ""
+	}
+
 	obj := typesInfo.Uses[sel.Sel]
 	if obj == nil {
-		return nil
+		return nil, ""
 	}
 
 	fn, ok := obj.(*types.Func)
 	if !ok {
-		return nil
+		return nil, ""
 	}
 
 	if fn.Pkg() == nil {
-		return nil
+		return nil, ""
+	}
+
+	if sig, _ := fn.Type().(*types.Signature); sig == nil || sig.Recv() == nil {
+		return nil, ""
 	}
 
 	if !errgroupPkgPathIsEnabled(cfg, fn.Pkg().Path()) {
-		return nil
+		return nil, ""
 	}
 
-	if len(callExpr.Args) != 1 {
-		return nil
+	return sel, sel.Sel.Name
+}
+
+// constructorReturnsContext reports whether one of the constructor's results
+// is a context.
+func constructorReturnsContext(constructor *ast.CallExpr, typesInfo *types.Info) bool {
+	tuple, ok := typesInfo.TypeOf(constructor).(*types.Tuple)
+	if !ok {
+		return false
 	}
 
-	funcLit, _ := callExpr.Args[0].(*ast.FuncLit)
+	for v := range tuple.Variables() {
+		if isContextType(v.Type()) {
+			return true
+		}
+	}
 
-	return funcLit
+	return false
 }
@@ -0,0 +1,120 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkUntrackedGoroutines reports go statements in a callback that capture
+// the derived context, when the callback may return without joining them: the
+// goroutine outlives the callback, is not waited for by the group and runs
+// with a context that Wait has already cancelled.
+func (fv *funcVisitor) checkUntrackedGoroutines(funcLit *ast.FuncLit, elem *errgroupStackElement) {
+	if elem.ctxObj == nil {
+		return
+	}
+
+	var (
+		goStmts []*ast.GoStmt
+		joined  bool
+		// Ranging over a channel is a join as well. The CFG only has the
+		// ranged expression as a node.
+		rangedChans = make(map[ast.Node]bool)
+	)
+
+	ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.GoStmt:
+			if fv.references(n, elem.ctxObj) {
+				goStmts = append(goStmts, n)
+			}
+
+			return false
+		case *ast.DeferStmt:
+			// A deferred join runs on every return.
+			joined = joined || fv.joins(n.Call)
+
+			return false
+		case *ast.RangeStmt:
+			if isChanType(fv.pass.TypesInfo.TypeOf(n.X)) && !fv.isClockOrDone(n.X) {
+				rangedChans[n.X] = true
+			}
+		}
+
+		return true
+	})
+
+	if joined {
+		return
+	}
+
+	joins := func(n ast.Node) bool { return rangedChans[n] || fv.joins(n) }
+
+	for _, goStmt := range goStmts {
+		if !mayReturnAfter(fv.funcCFG(funcLit), goStmt, joins) {
+			continue
+		}
+
+		fv.report(RuleUntrackedGo, goStmt, nil,
+			"goroutine started in errgroup callback uses %q but is never joined, it may outlive the group and run with a cancelled context",
+			elem.ctxName)
+	}
+}
+
+// references reports whether the node refers to the object.
+func (fv *funcVisitor) references(node ast.Node, obj types.Object) bool {
+	var found bool
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && fv.pass.TypesInfo.Uses[ident] == obj {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// joins reports whether the node waits for other goroutines: it receives from
+// a channel that may wait for them, or calls Wait on a sync.WaitGroup or on a
+// group. Done channels of contexts and time channels do not wait for anyone.
+func (fv *funcVisitor) joins(node ast.Node) bool {
+	var found bool
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.UnaryExpr:
+			found = n.Op == token.ARROW && !fv.isClockOrDone(n.X)
+		case *ast.CallExpr:
+			found = fv.isWaitCall(n)
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// isWaitCall reports whether the call is sync.WaitGroup.Wait or Wait on a
+// group.
+func (fv *funcVisitor) isWaitCall(call *ast.CallExpr) bool {
+	if _, method := errgroupMethodFromCallExpr(call, fv.pass.TypesInfo, fv.cfg); method == methodWait {
+		return true
+	}
+
+	fn, ok := typeutil.Callee(fv.pass.TypesInfo, call).(*types.Func)
+
+	return ok && fn.FullName() == "(*sync.WaitGroup).Wait"
+}
@@ -0,0 +1,197 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+)
+
+const (
+	methodGo       = "Go"
+	methodTryGo    = "TryGo"
+	methodWait     = "Wait"
+	methodSetLimit = "SetLimit"
+)
+
+// groupInfo accumulates everything the visitor learns about a single errgroup
+// construction site over the course of a pass.
+type groupInfo struct {
+	constructor *ast.CallExpr
+	// decl is the assignment or declaration statement of the group.
+	decl ast.Node
+	// fn is the innermost function declaration or literal containing the
+	// constructor call.
+	fn        ast.Node
+	groupObj  types.Object
+	groupName string
+	ctxObj    types.Object
+	ctxName   string
+
+	goCalls       []groupCall
+	tryGoCalls    []groupCall
+	waitCalls     []groupCall
+	setLimitCalls []groupCall
+
+	callbacks []groupCallback
+
+	flagged bool
+}
+
+// groupCallback is a function literal passed to Go or TryGo.
+type groupCallback struct {
+	call    *ast.CallExpr
+	funcLit *ast.FuncLit
+}
+
+// groupCall is a method call on a group.
+type groupCall struct {
+	call *ast.CallExpr
+	// fn is the innermost function declaration or literal containing the call.
+	fn ast.Node
+	// deferred is set when the call is the one made by a defer statement.
+	deferred bool
+	// resultIgnored is set when the results of the call are not used.
+	resultIgnored bool
+}
+
+// spawnCalls returns both the Go and the TryGo calls on the group.
+func (g *groupInfo) spawnCalls() []groupCall {
+	return append(slices.Clip(g.goCalls), g.tryGoCalls...)
+}
+
+// GroupReport describes a single errgroup found by the visitor. It is the
+// result of the analyzer and the building block of the inventory report.
+type GroupReport struct {
+	Position       string `json:"position"`
+	Constructor    string `json:"constructor"`
+	Group          string `json:"group"`
+	DerivedContext string `json:"derived_context,omitempty"`
+	SetLimitCalled bool   `json:"set_limit_called"`
+	SetLimit       *int64 `json:"set_limit,omitempty"`
+	GoSites        int    `json:"go_sites"`
+	TryGoSites     int    `json:"trygo_sites"`
+	WaitCalled     bool   `json:"wait_called"`
+	Flagged        bool   `json:"flagged"`
+}
+
+func (g *groupInfo) report(fset *token.FileSet, typesInfo *types.Info) GroupReport {
+	report := GroupReport{
+		Position:       fset.Position(g.constructor.Pos()).String(),
+		Constructor:    types.ExprString(g.constructor.Fun),
+		Group:          g.groupName,
+		DerivedContext: g.ctxName,
+		SetLimitCalled: len(g.setLimitCalls) > 0,
+		GoSites:        len(g.goCalls),
+		TryGoSites:     len(g.tryGoCalls),
+		WaitCalled:     len(g.waitCalls) > 0,
+		Flagged:        g.flagged,
+	}
+
+	if len(g.setLimitCalls) > 0 {
+		report.SetLimit = constantSetLimit(g.setLimitCalls[len(g.setLimitCalls)-1].call, typesInfo)
+	}
+
+	return report
+}
+
+// record remembers a method call on the group. Unknown methods are ignored.
+func (g *groupInfo) record(method string, call groupCall) {
+	switch method {
+	case methodGo:
+		g.goCalls = append(g.goCalls, call)
+	case methodTryGo:
+		g.tryGoCalls = append(g.tryGoCalls, call)
+	case methodWait:
+		g.waitCalls = append(g.waitCalls, call)
+	case methodSetLimit:
+		g.setLimitCalls = append(g.setLimitCalls, call)
+	}
+}
+
+// newGroupCall describes the call at the top of the stack.
+func newGroupCall(callExpr *ast.CallExpr, stack []ast.Node) groupCall {
+	call := groupCall{
+		call: callExpr,
+		fn:   enclosingFunc(stack),
+	}
+
+	if len(stack) < 2 {
+		return call
+	}
+
+	switch parent := stack[len(stack)-2].(type) {
+	case *ast.ExprStmt, *ast.GoStmt:
+		call.resultIgnored = true
+	case *ast.DeferStmt:
+		call.deferred = true
+		call.resultIgnored = true
+	case *ast.AssignStmt:
+		call.resultIgnored = assignedToBlank(parent.Lhs, parent.Rhs, callExpr)
+	case *ast.ValueSpec:
+		lhs := make([]ast.Expr, 0, len(parent.Names))
+		for _, name := range parent.Names {
+			lhs = append(lhs, name)
+		}
+
+		call.resultIgnored = assignedToBlank(lhs, parent.Values, callExpr)
+	}
+
+	return call
+}
+
+// assignedToBlank reports whether every value the call yields in the
+// assignment goes to the blank identifier.
+func assignedToBlank(lhs, rhs []ast.Expr, callExpr *ast.CallExpr) bool {
+	targets := lhs
+	if len(lhs) == len(rhs) {
+		i := slices.Index(rhs, ast.Expr(callExpr))
+		if i < 0 {
+			return false
+		}
+
+		targets = lhs[i : i+1]
+	}
+
+	for _, e := range targets {
+		if ident, ok := e.(*ast.Ident); !ok || ident.Name != "_" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// enclosingFunc returns the innermost function declaration or literal on the
+// stack.
+func enclosingFunc(stack []ast.Node) ast.Node {
+	for _, n := range slices.Backward(stack) {
+		switch n.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return n
+		}
+	}
+
+	return nil
+}
+
+// constantSetLimit returns the argument of a SetLimit call if it is a
+// constant integer.
+func constantSetLimit(callExpr *ast.CallExpr, typesInfo *types.Info) *int64 {
+	if len(callExpr.Args) != 1 {
+		return nil
+	}
+
+	tv, ok := typesInfo.Types[callExpr.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return nil
+	}
+
+	n, exact := constant.Int64Val(tv.Value)
+	if !exact {
+		return nil
+	}
+
+	return &n
+}
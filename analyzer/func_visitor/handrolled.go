@@ -0,0 +1,213 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// handRolledGoroutine is a go statement of a hand-rolled errgroup.
+type handRolledGoroutine struct {
+	goStmt  *ast.GoStmt
+	funcLit *ast.FuncLit
+}
+
+// checkHandRolledGroup recognizes "ctx, cancel := context.WithCancel(parent)"
+// followed by goroutines that call Done on a sync.WaitGroup, send to an error
+// channel and call cancel: an errgroup written by hand. Such goroutines are
+// also checked for references to the parent context, which is not cancelled
+// along with the others.
+func (fv *funcVisitor) checkHandRolledGroup(assignStmt *ast.AssignStmt, stack []ast.Node) {
+	if len(assignStmt.Lhs) != 2 || len(assignStmt.Rhs) != 1 {
+		return
+	}
+
+	call, ok := assignStmt.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return
+	}
+
+	fn, ok := typeutil.Callee(fv.pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "context" || (fn.Name() != "WithCancel" && fn.Name() != "WithCancelCause") {
+		return
+	}
+
+	ctxIdent, _ := assignStmt.Lhs[0].(*ast.Ident)
+	cancelIdent, _ := assignStmt.Lhs[1].(*ast.Ident)
+	if ctxIdent == nil || cancelIdent == nil || cancelIdent.Name == "_" {
+		return
+	}
+
+	body := enclosingFunc(stack)
+	if body == nil {
+		return
+	}
+
+	goroutines := fv.handRolledGoroutines(body, fv.pass.TypesInfo.ObjectOf(cancelIdent))
+	if len(goroutines) == 0 {
+		return
+	}
+
+	related := make([]analysis.RelatedInformation, 0, len(goroutines))
+	for _, g := range goroutines {
+		related = append(related, relatedTo(g.goStmt, "goroutine is started here"))
+	}
+
+	fv.report(RuleHandRolledGroup, call, related,
+		"sync.WaitGroup, an error channel and %s emulate an errgroup, use %s.WithContext instead",
+		types.ExprString(call.Fun), fv.cfg.ErrgroupPackagePaths[0])
+
+	parentIdent, ok := ast.Unparen(call.Args[0]).(*ast.Ident)
+	if !ok || ctxIdent.Name == "_" {
+		return
+	}
+
+	parentObj := fv.pass.TypesInfo.Uses[parentIdent]
+	ctxObj := fv.pass.TypesInfo.ObjectOf(ctxIdent)
+	if parentObj == nil || parentObj == ctxObj {
+		return
+	}
+
+	// The cancellable context plays the part of the derived context of an
+	// errgroup.
+	elem := &errgroupStackElement{ctxObj: ctxObj, ctxName: ctxIdent.Name}
+
+	for _, g := range goroutines {
+		fv.reportParentContextRefs(g.funcLit, parentObj, elem)
+	}
+}
+
+// handRolledGoroutines returns the goroutines started within the function
+// that call Done on a sync.WaitGroup, send to an error channel and call the
+// cancel function.
+func (fv *funcVisitor) handRolledGoroutines(fn ast.Node, cancelObj types.Object) []handRolledGoroutine {
+	var goroutines []handRolledGoroutine
+
+	ast.Inspect(fn, func(n ast.Node) bool {
+		goStmt, ok := n.(*ast.GoStmt)
+		if !ok {
+			return true
+		}
+
+		funcLit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+
+		var done, sends, cancels bool
+
+		ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SendStmt:
+				if ch, ok := fv.pass.TypesInfo.TypeOf(n.Chan).Underlying().(*types.Chan); ok && isErrorType(ch.Elem()) {
+					sends = true
+				}
+			case *ast.CallExpr:
+				if ident, ok := ast.Unparen(n.Fun).(*ast.Ident); ok && fv.pass.TypesInfo.Uses[ident] == cancelObj {
+					cancels = true
+				}
+
+				if fn, ok := typeutil.Callee(fv.pass.TypesInfo, n).(*types.Func); ok && fn.FullName() == "(*sync.WaitGroup).Done" {
+					done = true
+				}
+			}
+
+			return true
+		})
+
+		if done && sends && cancels {
+			goroutines = append(goroutines, handRolledGoroutine{goStmt: goStmt, funcLit: funcLit})
+		}
+
+		return true
+	})
+
+	return goroutines
+}
+
+// reportParentContextRefs reports references to the parent context within a
+// goroutine of a hand-rolled errgroup, directly or through local closures and
+// struct values, as the outer-context rule does for errgroup callbacks.
+func (fv *funcVisitor) reportParentContextRefs(funcLit *ast.FuncLit, parentObj types.Object, elem *errgroupStackElement) {
+	explain := fv.explainer.covers(fv.pass.Fset, funcLit)
+
+	var captures []capture
+
+	ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			captures = append(captures, fv.structCaptures(sel, funcLit, elem)...)
+
+			return true
+		}
+
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		obj, ok := fv.pass.TypesInfo.Uses[ident].(*types.Var)
+		if !ok {
+			return true
+		}
+
+		if helper := fv.localClosure(ident); helper != nil && !contains(funcLit, helper) {
+			captures = append(captures, fv.helperCaptures(ident, helper, elem, nil)...)
+
+			return true
+		}
+
+		if !isContextType(obj.Type()) {
+			return true
+		}
+
+		outer, reason := fv.classifyContextRef(ident, obj, funcLit, elem)
+		if explain && fv.explainer.coversPos(fv.pass.Fset, ident.Pos()) {
+			fv.explainer.printf("%s %q: %s", fv.pass.Fset.Position(ident.Pos()), ident.Name, reason)
+		}
+
+		if !outer || obj != parentObj {
+			return true
+		}
+
+		var related []analysis.RelatedInformation
+		if decl := fv.relatedToDeclaration(ident); decl != nil {
+			related = append(related, *decl)
+		}
+
+		fv.reportParentContextRef(ident, ident.Name, related, elem)
+
+		return true
+	})
+
+	for _, c := range fv.uniqueCaptures(captures) {
+		if fv.pass.TypesInfo.Uses[c.capture] != parentObj {
+			continue
+		}
+
+		if explain && fv.explainer.coversPos(fv.pass.Fset, c.ref.Pos()) {
+			fv.explainer.printf("%s %q: outer: carries %q captured at %s",
+				fv.pass.Fset.Position(c.ref.Pos()), c.ref.Name, c.capture.Name, fv.pass.Fset.Position(c.capture.Pos()))
+		}
+
+		fv.reportParentContextRef(c.ref, c.capture.Name, []analysis.RelatedInformation{
+			relatedTo(c.capture, "parent context %q is captured here by %q", c.capture.Name, c.ref.Name),
+		}, elem)
+	}
+}
+
+func (fv *funcVisitor) reportParentContextRef(
+	node ast.Node,
+	parentName string,
+	related []analysis.RelatedInformation,
+	elem *errgroupStackElement,
+) {
+	fv.report(RuleHandRolledGroup, node, related,
+		"goroutine should probably not reference the parent context %q, use the cancellable context %q",
+		parentName, elem.ctxName)
+}
+
+func isErrorType(typ types.Type) bool {
+	return types.Identical(typ, types.Universe.Lookup("error").Type())
+}
@@ -0,0 +1,235 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// capture is an outer context referenced by a callback through a local
+// closure, e.g. "f" in "f := func() error { return doSmth(ctx) }" followed by
+// "eg.Go(func() error { return f() })".
+type capture struct {
+	// ref is the reference to the closure within the callback.
+	ref *ast.Ident
+	// capture is the reference to the outer context within the closure, or
+	// within a closure it references in turn.
+	capture *ast.Ident
+}
+
+// checkCallbackValue checks a local closure passed to Go or TryGo by name, as
+// in "eg.Go(f)", a method value of a local struct, as in "eg.Go(w.Run)", and
+// a callback returned by a factory, as in "eg.Go(makeWorker(ctx, item))".
+func (fv *funcVisitor) checkCallbackValue(callExpr *ast.CallExpr, elem *errgroupStackElement, kind string) {
+	if elem.ctxObj == nil || (kind != methodGo && kind != methodTryGo) || len(callExpr.Args) != 1 {
+		return
+	}
+
+	derivedName := elem.ctxName
+	if derivedName == "" {
+		derivedName = "<errgroup context>"
+	}
+
+	var (
+		captures  []capture
+		outerArgs []*ast.Ident
+	)
+
+	switch arg := ast.Unparen(callExpr.Args[0]).(type) {
+	case *ast.Ident:
+		if helper := fv.localClosure(arg); helper != nil {
+			captures = fv.helperCaptures(arg, helper, elem, nil)
+		}
+	case *ast.SelectorExpr:
+		captures = fv.structCaptures(arg, callExpr, elem)
+	case *ast.CallExpr:
+		outerArgs = fv.checkCallbackFactory(arg, elem, derivedName, kind)
+	}
+
+	if fv.cfg.AggregateByCallback {
+		outerRefs := outerArgs
+		for _, c := range captures {
+			outerRefs = append(outerRefs, c.capture)
+		}
+
+		fv.reportOuterContextsOfCallback(callExpr.Args[0], elem, outerRefs, derivedName, kind)
+
+		return
+	}
+
+	for _, ident := range outerArgs {
+		fv.reportOuterContext(ident, elem, derivedName, kind)
+	}
+
+	for _, c := range fv.uniqueCaptures(captures) {
+		fv.reportCapturedOuterContext(c, elem, derivedName, kind)
+	}
+}
+
+// uniqueCaptures drops captures of a context already captured through the same
+// reference, which would be reported at the same position.
+func (fv *funcVisitor) uniqueCaptures(captures []capture) []capture {
+	type key struct {
+		ref *ast.Ident
+		obj types.Object
+	}
+
+	var (
+		unique []capture
+		seen   = make(map[key]bool)
+	)
+
+	for _, c := range captures {
+		k := key{c.ref, fv.pass.TypesInfo.Uses[c.capture]}
+		if !seen[k] {
+			seen[k] = true
+			unique = append(unique, c)
+		}
+	}
+
+	return unique
+}
+
+// localClosure returns the function literal a local variable is initialized
+// with where it is declared.
+func (fv *funcVisitor) localClosure(ident *ast.Ident) *ast.FuncLit {
+	obj := fv.localVar(ident)
+	if obj == nil {
+		return nil
+	}
+
+	if _, ok := obj.Type().Underlying().(*types.Signature); !ok {
+		return nil
+	}
+
+	funcLit, _ := ast.Unparen(fv.initializer(obj)).(*ast.FuncLit)
+
+	return funcLit
+}
+
+// localVar returns the variable the identifier refers to, if it is declared
+// within a function.
+func (fv *funcVisitor) localVar(ident *ast.Ident) *types.Var {
+	obj, ok := fv.pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || obj.Pkg() == nil || obj.Parent() == nil || obj.Parent() == obj.Pkg().Scope() {
+		return nil
+	}
+
+	return obj
+}
+
+// initializer returns the expression a variable is initialized with where it
+// is declared, if any.
+func (fv *funcVisitor) initializer(obj types.Object) ast.Expr {
+	for _, n := range fv.pathTo(obj.Pos()) {
+		var lhs, rhs []ast.Expr
+
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			lhs, rhs = n.Lhs, n.Rhs
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				lhs = append(lhs, name)
+			}
+			rhs = n.Values
+		default:
+			continue
+		}
+
+		if len(lhs) != len(rhs) {
+			return nil
+		}
+
+		for i, e := range lhs {
+			if id, ok := e.(*ast.Ident); ok && fv.pass.TypesInfo.Defs[id] == obj {
+				return rhs[i]
+			}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// helperCaptures returns the outer contexts the closure references, directly
+// or through other local closures. Contexts declared within the closure, such
+// as its parameters, are not outer.
+func (fv *funcVisitor) helperCaptures(
+	ref *ast.Ident,
+	helper *ast.FuncLit,
+	elem *errgroupStackElement,
+	visited map[*ast.FuncLit]bool,
+) []capture {
+	if visited == nil {
+		visited = make(map[*ast.FuncLit]bool)
+	}
+
+	if visited[helper] {
+		return nil
+	}
+
+	visited[helper] = true
+
+	var captures []capture
+
+	ast.Inspect(helper.Body, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		obj, ok := fv.pass.TypesInfo.Uses[ident].(*types.Var)
+		if !ok {
+			return true
+		}
+
+		if next := fv.localClosure(ident); next != nil && !contains(helper, next) {
+			for _, c := range fv.helperCaptures(ident, next, elem, visited) {
+				captures = append(captures, capture{ref: ref, capture: c.capture})
+			}
+
+			return true
+		}
+
+		if !isContextType(obj.Type()) {
+			return true
+		}
+
+		if outer, _ := fv.classifyContextRef(ident, obj, helper, elem); outer {
+			captures = append(captures, capture{ref: ref, capture: ident})
+		}
+
+		return true
+	})
+
+	return captures
+}
+
+// reportCapturedOuterContext reports an outer context referenced through a
+// local closure at the reference to the closure.
+func (fv *funcVisitor) reportCapturedOuterContext(c capture, elem *errgroupStackElement, derivedName, kind string) {
+	related := []analysis.RelatedInformation{
+		relatedTo(elem.info.constructor, "errgroup %q is created here", elem.groupObj.Name()),
+		relatedTo(c.capture, "outer context %q is captured here by %q", c.capture.Name, c.ref.Name),
+	}
+
+	message := fv.outerContextMessage(MessageData{
+		OuterContext:   c.capture.Name,
+		OuterContexts:  []string{c.capture.Name},
+		DerivedContext: derivedName,
+		Group:          elem.groupObj.Name(),
+		Rule:           RuleOuterContext,
+		CallbackKind:   kind,
+	})
+
+	if fv.report(RuleOuterContext, c.ref, related, "%s", message) {
+		elem.info.flagged = true
+	}
+}
+
+// contains reports whether the inner node lies within the outer one.
+func contains(outer, inner ast.Node) bool {
+	return outer.Pos() <= inner.Pos() && inner.End() <= outer.End()
+}
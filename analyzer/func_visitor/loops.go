@@ -0,0 +1,144 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkUncancellableLoops reports unbounded and polling loops in an errgroup
+// callback that never consult the derived context: they keep running after a
+// sibling callback fails and the group is cancelled.
+func (fv *funcVisitor) checkUncancellableLoops(funcLit *ast.FuncLit, elem *errgroupStackElement) {
+	if elem.ctxObj == nil {
+		return
+	}
+
+	ast.PreorderStack(funcLit.Body, nil, func(n ast.Node, stack []ast.Node) bool {
+		var kind string
+
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Nested callbacks are checked on their own.
+			if call, ok := parent(stack).(*ast.CallExpr); ok && tryGetErrgroupClosureFromCallExpr(call, fv.pass.TypesInfo, fv.cfg) == n {
+				return false
+			}
+
+			return true
+		case *ast.ForStmt:
+			switch {
+			case n.Cond == nil:
+				kind = "unbounded loop"
+			case fv.polls(n.Body):
+				kind = "polling loop"
+			}
+		case *ast.RangeStmt:
+			// Ranging over a ticker's channel waits for it on every
+			// iteration.
+			if fv.isTimeChannel(n.X) || fv.polls(n.Body) {
+				kind = "polling loop"
+			}
+		}
+
+		if kind == "" || fv.observesCancellation(n, funcLit, elem) {
+			return true
+		}
+
+		fv.report(RuleUncancellableLoop, loopKeyword(n), nil,
+			"%s in errgroup callback never observes %q, it keeps running after the group is cancelled",
+			kind, elem.ctxName)
+
+		return false
+	})
+}
+
+// polls reports whether the loop body sleeps or waits for a timer.
+func (fv *funcVisitor) polls(body *ast.BlockStmt) bool {
+	var polls bool
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if polls {
+			return false
+		}
+
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			fn, _ := typeutil.Callee(fv.pass.TypesInfo, n).(*types.Func)
+			if fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == "time" &&
+				slices.Contains([]string{"Sleep", "Tick", "After"}, fn.Name()) {
+				polls = true
+			}
+		}
+
+		return true
+	})
+
+	return polls
+}
+
+// observesCancellation reports whether the loop references the group's
+// context, or a context declared inside the callback, or calls one of the
+// configured cancellation-aware functions.
+func (fv *funcVisitor) observesCancellation(loop ast.Node, funcLit *ast.FuncLit, elem *errgroupStackElement) bool {
+	var observes bool
+
+	ast.Inspect(loop, func(n ast.Node) bool {
+		if observes {
+			return false
+		}
+
+		switch n := n.(type) {
+		case *ast.Ident:
+			obj, ok := fv.pass.TypesInfo.Uses[n].(*types.Var)
+			if !ok || !isContextType(obj.Type()) {
+				return true
+			}
+
+			if obj == elem.ctxObj || (obj.Pos() >= funcLit.Pos() && obj.Pos() < funcLit.End()) {
+				observes = true
+			}
+		case *ast.CallExpr:
+			fn, _ := typeutil.Callee(fv.pass.TypesInfo, n).(*types.Func)
+			if fn != nil && slices.Contains(fv.cfg.CancellationAwareCalls, qualifiedFuncName(fn)) {
+				observes = true
+			}
+		}
+
+		return true
+	})
+
+	return observes
+}
+
+// qualifiedFuncName names a function "pkg/path.Func" and a method
+// "pkg/path.Type.Method".
+func qualifiedFuncName(fn *types.Func) string {
+	if fn.Pkg() == nil {
+		return fn.Name()
+	}
+
+	name := fn.Name()
+
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		recv := sig.Recv().Type()
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem()
+		}
+
+		if named, ok := types.Unalias(recv).(*types.Named); ok {
+			name = named.Obj().Name() + "." + name
+		}
+	}
+
+	return strings.Join([]string{fn.Pkg().Path(), name}, ".")
+}
+
+// loopKeyword returns a node spanning the "for" keyword of the loop.
+func loopKeyword(loop ast.Node) ast.Node {
+	return &ast.Ident{NamePos: loop.Pos(), Name: "for"}
+}
@@ -0,0 +1,74 @@
+package func_visitor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// MessageData is what a custom message template is executed against.
+type MessageData struct {
+	// OuterContext is the name of the referenced outer context, the first one
+	// if several are listed.
+	OuterContext string
+	// OuterContexts lists every distinct outer context the diagnostic is
+	// about. It has more than one entry only with AggregateByCallback.
+	OuterContexts []string
+	// DerivedContext is the name of the errgroup-derived context.
+	DerivedContext string
+	// Group is the name of the errgroup variable.
+	Group string
+	// Rule is the ID of the rule that produced the diagnostic.
+	Rule string
+	// CallbackKind is the errgroup method the callback is passed to, "Go" or
+	// "TryGo".
+	CallbackKind string
+}
+
+func parseMessageTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message template: %w", err)
+	}
+
+	// Field names are only resolved on execution, so try it on sample data.
+	sample := MessageData{
+		OuterContext:   "ctx",
+		OuterContexts:  []string{"ctx"},
+		DerivedContext: "egCtx",
+		Group:          "eg",
+		Rule:           RuleOuterContext,
+		CallbackKind:   methodGo,
+	}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, fmt.Errorf("invalid message template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// outerContextMessage renders the message about outer contexts referenced
+// from an errgroup callback, using the configured template if there is one.
+func (fv *funcVisitor) outerContextMessage(data MessageData) string {
+	if fv.cfg.messageTemplate != nil {
+		var b strings.Builder
+		if err := fv.cfg.messageTemplate.Execute(&b, data); err == nil {
+			return b.String()
+		}
+	}
+
+	quoted := make([]string, 0, len(data.OuterContexts))
+	for _, name := range data.OuterContexts {
+		quoted = append(quoted, fmt.Sprintf("%q", name))
+	}
+
+	noun := "context"
+	if len(quoted) > 1 {
+		noun = "contexts"
+	}
+
+	return fmt.Sprintf(
+		"errgroup callback should probably not reference outer %s %s, use the errgroup-derived context %q",
+		noun, strings.Join(quoted, ", "), data.DerivedContext)
+}
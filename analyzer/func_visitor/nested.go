@@ -0,0 +1,151 @@
+package func_visitor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkNestedGroup reports groups created inside a callback of another group
+// with a derived context, but not derived from that context: cancelling the
+// enclosing group does not cancel the nested one. It returns whether the group
+// was reported.
+func (fv *funcVisitor) checkNestedGroup(g *groupInfo, stack []ast.Node) bool {
+	funcLit, enclosing := fv.enclosingCallback(stack)
+
+	arg, parent := fv.nestedGroupDerivation(g.constructor, funcLit, enclosing)
+	if arg == nil {
+		return false
+	}
+
+	related := []analysis.RelatedInformation{
+		relatedTo(enclosing.info.constructor, "enclosing errgroup %q is created here", enclosing.info.groupName),
+	}
+
+	if !fv.report(RuleNestedGroup, arg, related,
+		"nested errgroup %q is derived from %s instead of %q, the context of the enclosing errgroup %q, so it is not cancelled along with it",
+		g.groupName, parent, enclosing.ctxName, enclosing.info.groupName) {
+		return false
+	}
+
+	enclosing.info.flagged = true
+
+	return true
+}
+
+// nestedGroupDerivation returns the parent argument of a group constructor
+// called within the callback of the enclosing group, along with a description
+// of it, if the nested group is not derived from the enclosing group's
+// context.
+func (fv *funcVisitor) nestedGroupDerivation(
+	constructor *ast.CallExpr,
+	funcLit *ast.FuncLit,
+	enclosing *errgroupStackElement,
+) (ast.Expr, string) {
+	if enclosing == nil || enclosing.ctxObj == nil ||
+		len(constructor.Args) == 0 || !constructorReturnsContext(constructor, fv.pass.TypesInfo) {
+		return nil, ""
+	}
+
+	switch arg := ast.Unparen(constructor.Args[0]).(type) {
+	case *ast.Ident:
+		obj, ok := fv.pass.TypesInfo.Uses[arg].(*types.Var)
+		if !ok || !isContextType(obj.Type()) {
+			return nil, ""
+		}
+
+		if outer, _ := fv.classifyContextRef(arg, obj, funcLit, enclosing); !outer {
+			return nil, ""
+		}
+
+		return arg, fmt.Sprintf("outer context %q", arg.Name)
+	case *ast.CallExpr:
+		if parent := fv.emptyContextCall(arg); parent != "" {
+			return arg, parent
+		}
+	}
+
+	return nil, ""
+}
+
+// enclosingCallback returns the innermost Go or TryGo callback on the stack
+// along with its group.
+func (fv *funcVisitor) enclosingCallback(stack []ast.Node) (*ast.FuncLit, *errgroupStackElement) {
+	for i := len(stack) - 1; i > 0; i-- {
+		funcLit, ok := stack[i].(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+
+		call, ok := stack[i-1].(*ast.CallExpr)
+		if !ok || tryGetErrgroupClosureFromCallExpr(call, fv.pass.TypesInfo, fv.cfg) != funcLit {
+			continue
+		}
+
+		sel, _ := errgroupMethodFromCallExpr(call, fv.pass.TypesInfo, fv.cfg)
+
+		xIdent, ok := sel.X.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		if elem := fv.errgroupStack.FindByGroup(fv.pass.TypesInfo.ObjectOf(xIdent)); elem != nil {
+			return funcLit, elem
+		}
+	}
+
+	return nil, nil
+}
+
+// nestedGroupParent returns the context identifier a group is derived from if
+// checkNestedGroup reports it: the call is a group constructor assigned to a
+// group variable within funcLit, the callback of the enclosing group, and it
+// is not derived from the enclosing group's context.
+func (fv *funcVisitor) nestedGroupParent(
+	call *ast.CallExpr,
+	parent ast.Node,
+	funcLit *ast.FuncLit,
+	enclosing *errgroupStackElement,
+) *ast.Ident {
+	if !callExprPkgIsErrgroup(call, fv.pass.TypesInfo, fv.cfg) {
+		return nil
+	}
+
+	var idents []*ast.Ident
+
+	switch parent := parent.(type) {
+	case *ast.AssignStmt:
+		if len(parent.Rhs) != 1 || parent.Rhs[0] != call {
+			return nil
+		}
+
+		for _, e := range parent.Lhs {
+			if id, ok := e.(*ast.Ident); ok {
+				idents = append(idents, id)
+			}
+		}
+	case *ast.ValueSpec:
+		if len(parent.Values) != 1 || parent.Values[0] != call {
+			return nil
+		}
+
+		idents = parent.Names
+	default:
+		return nil
+	}
+
+	var elem errgroupStackElement
+
+	fillStackElemFromIdents(&elem, idents, fv.pass.TypesInfo, fv.cfg)
+
+	if elem.groupObj == nil {
+		return nil
+	}
+
+	arg, _ := fv.nestedGroupDerivation(call, funcLit, enclosing)
+	ident, _ := arg.(*ast.Ident)
+
+	return ident
+}
@@ -0,0 +1,115 @@
+package func_visitor
+
+import (
+	"fmt"
+	"go/ast"
+	"maps"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// DocsURL is the location of the rules documentation. Every rule has an anchor
+// there named after its ID.
+const DocsURL = "https://github.com/m-ocean-it/errgroup-ctx-lint"
+
+const (
+	RuleOuterContext      = "outer-context"
+	RuleSetLimit          = "setlimit"
+	RuleTryGoResult       = "trygo-result"
+	RuleMissingWait       = "missing-wait"
+	RuleUncheckedWait     = "unchecked-wait"
+	RuleWaitInCallback    = "wait-in-callback"
+	RuleGoAfterWait       = "go-after-wait"
+	RuleBlockingChannel   = "blocking-channel"
+	RuleUncancellableLoop = "uncancellable-loop"
+	RuleUnusedContext     = "unused-derived-context"
+	RuleShadowContext     = "shadow-context"
+	RuleNestedGroup       = "nested-group"
+	RuleDetachedRoot      = "detached-root"
+	RuleUntrackedGo       = "untracked-goroutine"
+	RuleUnboundedFanOut   = "unbounded-fanout"
+	RuleHandRolledGroup   = "hand-rolled-group"
+	RuleSwallowedError    = "swallowed-error"
+	RuleDerivedContext    = "derived-context"
+)
+
+// defaultRules maps every known rule to whether it runs by default.
+var defaultRules = map[string]bool{
+	RuleOuterContext:      true,
+	RuleSetLimit:          true,
+	RuleTryGoResult:       true,
+	RuleMissingWait:       false,
+	RuleUncheckedWait:     false,
+	RuleWaitInCallback:    true,
+	RuleGoAfterWait:       true,
+	RuleBlockingChannel:   false,
+	RuleUncancellableLoop: false,
+	RuleUnusedContext:     false,
+	RuleShadowContext:     false,
+	RuleNestedGroup:       true,
+	RuleDetachedRoot:      true,
+	RuleUntrackedGo:       false,
+	RuleUnboundedFanOut:   false,
+	RuleHandRolledGroup:   false,
+	RuleSwallowedError:    false,
+	// Reported by the derived context analyzer, which is off when it is
+	// disabled.
+	RuleDerivedContext: true,
+}
+
+// Rules returns the IDs of all known rules, in alphabetical order.
+func Rules() []string {
+	return slices.Sorted(maps.Keys(defaultRules))
+}
+
+func ruleURL(rule string) string {
+	return DocsURL + "#" + rule
+}
+
+// report emits a diagnostic for the rule spanning the node, unless the node's
+// line is suppressed with a nolint comment.
+func (fv *funcVisitor) report(
+	rule string,
+	node ast.Node,
+	related []analysis.RelatedInformation,
+	format string,
+	args ...any,
+) bool {
+	return fv.reportWithFixes(rule, node, related, nil, format, args...)
+}
+
+// reportWithFixes is like report, but attaches suggested fixes to the
+// diagnostic.
+func (fv *funcVisitor) reportWithFixes(
+	rule string,
+	node ast.Node,
+	related []analysis.RelatedInformation,
+	fixes []analysis.SuggestedFix,
+	format string,
+	args ...any,
+) bool {
+	if positionIsNoLint(node.Pos(), fv.pass.Fset, fv.nolintLines) {
+		return false
+	}
+
+	fv.pass.Report(analysis.Diagnostic{
+		Pos:            node.Pos(),
+		End:            node.End(),
+		Category:       rule,
+		URL:            ruleURL(rule),
+		Message:        fmt.Sprintf(format, args...),
+		Related:        related,
+		SuggestedFixes: fixes,
+	})
+
+	return true
+}
+
+func relatedTo(node ast.Node, format string, args ...any) analysis.RelatedInformation {
+	return analysis.RelatedInformation{
+		Pos:     node.Pos(),
+		End:     node.End(),
+		Message: fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,49 @@
+package func_visitor
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkSetLimit reports SetLimit calls that may run while the group has active
+// goroutines, which panics, and SetLimit(0), which blocks every Go forever.
+func (fv *funcVisitor) checkSetLimit(g *groupInfo) {
+	// Neither a fresh group nor one that has been waited for has goroutines.
+	var (
+		reassigns = assignsObject(g.groupObj, fv.pass.TypesInfo)
+		waits     = containsCall(g.waitCalls)
+		stop      = func(n ast.Node) bool { return reassigns(n) || waits(n) }
+	)
+
+	for _, setLimit := range g.setLimitCalls {
+		if n := constantSetLimit(setLimit.call, fv.pass.TypesInfo); n != nil && *n == 0 {
+			fv.report(RuleSetLimit, setLimit.call, nil,
+				"SetLimit(0) on errgroup %q makes every Go call block forever and every TryGo call fail",
+				g.groupName)
+		}
+
+		if setLimit.deferred {
+			continue
+		}
+
+		for _, spawn := range g.spawnCalls() {
+			if spawn.deferred || spawn.fn != setLimit.fn {
+				continue
+			}
+
+			if !mayFollow(fv.funcCFG(setLimit.fn), spawn.call, setLimit.call, stop) {
+				continue
+			}
+
+			method := spawn.call.Fun.(*ast.SelectorExpr).Sel.Name // safe: recorded calls are method calls
+
+			fv.report(RuleSetLimit, setLimit.call, []analysis.RelatedInformation{
+				relatedTo(spawn.call, "%s is called here", method),
+			}, "SetLimit on errgroup %q may be called after %s, it panics while goroutines are active",
+				g.groupName, method)
+
+			break
+		}
+	}
+}
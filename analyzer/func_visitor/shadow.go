@@ -0,0 +1,173 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkShadowContext reports derived contexts that do not shadow the context
+// they are derived from, e.g. "eg, egCtx := errgroup.WithContext(ctx)". With
+// "eg, ctx := errgroup.WithContext(ctx)" the parent cannot be referenced by
+// mistake afterwards.
+func (fv *funcVisitor) checkShadowContext(g *groupInfo) {
+	if g.ctxObj == nil || len(g.constructor.Args) == 0 {
+		return
+	}
+
+	parentIdent, ok := ast.Unparen(g.constructor.Args[0]).(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	parentObj, ok := fv.pass.TypesInfo.Uses[parentIdent].(*types.Var)
+	if !ok || parentObj.Name() == g.ctxObj.Name() || !isContextType(parentObj.Type()) {
+		return
+	}
+
+	ctxIdent := declaredIdent(g.decl, g.ctxObj, fv.pass.TypesInfo)
+	if ctxIdent == nil {
+		return
+	}
+
+	var fixes []analysis.SuggestedFix
+	if edits := fv.renameEdits(g, ctxIdent, parentObj); edits != nil {
+		fixes = []analysis.SuggestedFix{{
+			Message:   "Rename " + g.ctxName + " to " + parentObj.Name(),
+			TextEdits: edits,
+		}}
+	}
+
+	fv.reportWithFixes(RuleShadowContext, ctxIdent, nil, fixes,
+		"derived context %q of errgroup %q should shadow its parent context %q, so that the parent cannot be referenced by mistake",
+		g.ctxName, g.groupName, parentObj.Name())
+}
+
+// renameEdits renames the derived context and all its uses after the parent
+// context. It returns nil if the rename would change what some identifier
+// refers to or would not compile.
+func (fv *funcVisitor) renameEdits(g *groupInfo, ctxIdent *ast.Ident, parentObj *types.Var) []analysis.TextEdit {
+	var (
+		name      = parentObj.Name()
+		scope     = g.ctxObj.Parent()
+		typesInfo = fv.pass.TypesInfo
+	)
+
+	if scope == nil {
+		return nil
+	}
+
+	if other := scope.Lookup(name); other != nil && other != parentObj {
+		return nil
+	}
+
+	if parentObj.Parent() == scope {
+		// The derived context would be assigned to the parent variable
+		// instead of declaring a new one.
+		assign, ok := g.decl.(*ast.AssignStmt)
+		if !ok || !types.Identical(parentObj.Type(), g.ctxObj.Type()) || !declaresOther(assign, ctxIdent, typesInfo) {
+			return nil
+		}
+
+		// Closures, deferred calls and goroutines would see the derived
+		// context once assigned, even if they are created before.
+		if fv.referencedLater(g.fn, parentObj) {
+			return nil
+		}
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     ctxIdent.Pos(),
+		End:     ctxIdent.End(),
+		NewText: []byte(name),
+	}}
+
+	for ident, obj := range typesInfo.Uses {
+		switch obj {
+		case parentObj:
+			// Later references to the parent would refer to the derived
+			// context once it is renamed.
+			if ident.Pos() > g.constructor.End() && scope.Contains(ident.Pos()) {
+				return nil
+			}
+		case g.ctxObj:
+			// The parent name must not be taken by something declared
+			// between the derived context and its use.
+			inner := fv.pass.Pkg.Scope().Innermost(ident.Pos())
+			if inner == nil {
+				return nil
+			}
+
+			if _, found := inner.LookupParent(name, ident.Pos()); found != nil && found != parentObj {
+				return nil
+			}
+
+			edits = append(edits, analysis.TextEdit{
+				Pos:     ident.Pos(),
+				End:     ident.End(),
+				NewText: []byte(name),
+			})
+		}
+	}
+
+	return edits
+}
+
+// referencedLater reports whether the object is referenced within the function
+// by code that may run after the statements following it: function literals,
+// deferred calls and go statements.
+func (fv *funcVisitor) referencedLater(fn ast.Node, obj types.Object) bool {
+	var body *ast.BlockStmt
+
+	switch fn := fn.(type) {
+	case *ast.FuncDecl:
+		body = fn.Body
+	case *ast.FuncLit:
+		body = fn.Body
+	}
+
+	if body == nil {
+		return true
+	}
+
+	var found bool
+
+	ast.PreorderStack(body, nil, func(n ast.Node, stack []ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if found || !ok || fv.pass.TypesInfo.Uses[ident] != obj {
+			return !found
+		}
+
+		found = slices.ContainsFunc(stack, func(n ast.Node) bool {
+			switch n.(type) {
+			case *ast.FuncLit, *ast.DeferStmt, *ast.GoStmt:
+				return true
+			}
+
+			return false
+		})
+
+		return !found
+	})
+
+	return found
+}
+
+// declaresOther reports whether the short variable declaration declares a new
+// variable other than the given one.
+func declaresOther(assign *ast.AssignStmt, ident *ast.Ident, typesInfo *types.Info) bool {
+	if assign.Tok != token.DEFINE {
+		return false
+	}
+
+	for _, e := range assign.Lhs {
+		if id, ok := e.(*ast.Ident); ok && id != ident && typesInfo.Defs[id] != nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,128 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// structCaptures returns the outer contexts a local struct value carries into
+// the callback through a method call or method value, as in
+// "w := &worker{ctx: ctx}" followed by "eg.Go(func() error { return w.Run() })".
+// The contexts are stored in a composite literal the variable is initialized
+// with, or in field assignments outside the callback.
+func (fv *funcVisitor) structCaptures(sel *ast.SelectorExpr, scope ast.Node, elem *errgroupStackElement) []capture {
+	ref, ok := ast.Unparen(sel.X).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	obj := fv.localVar(ref)
+	if obj == nil || (obj.Pos() >= scope.Pos() && obj.Pos() < scope.End()) {
+		return nil
+	}
+
+	if !fv.isStructMethod(sel) {
+		return nil
+	}
+
+	var captures []capture
+
+	add := func(expr ast.Expr) {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		if !ok {
+			return
+		}
+
+		ctxObj, ok := fv.pass.TypesInfo.Uses[ident].(*types.Var)
+		if !ok || !isContextType(ctxObj.Type()) {
+			return
+		}
+
+		if outer, _ := fv.classifyContextRef(ident, ctxObj, scope, elem); outer {
+			captures = append(captures, capture{ref: ref, capture: ident})
+		}
+	}
+
+	init := ast.Unparen(fv.initializer(obj))
+	if unary, ok := init.(*ast.UnaryExpr); ok {
+		init = ast.Unparen(unary.X)
+	}
+
+	if lit, ok := init.(*ast.CompositeLit); ok {
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+
+			add(elt)
+		}
+	}
+
+	body := fv.enclosingBody(obj)
+	if body == nil {
+		return captures
+	}
+
+	// Field stores, e.g. "w.ctx = ctx", outside the callback.
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n != nil && contains(scope, n) {
+			return false
+		}
+
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+
+		for i, lhs := range assign.Lhs {
+			field, ok := ast.Unparen(lhs).(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+
+			if x, ok := ast.Unparen(field.X).(*ast.Ident); ok && fv.pass.TypesInfo.Uses[x] == obj {
+				add(assign.Rhs[i])
+			}
+		}
+
+		return true
+	})
+
+	return captures
+}
+
+// isStructMethod reports whether the selector is a method of a struct value,
+// through which a context stored in the value can be used. Fields of context
+// type are references to an outer context on their own.
+func (fv *funcVisitor) isStructMethod(sel *ast.SelectorExpr) bool {
+	selection := fv.pass.TypesInfo.Selections[sel]
+	if selection == nil {
+		return false
+	}
+
+	recv := selection.Recv()
+	if ptr, ok := recv.Underlying().(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+
+	if _, ok := recv.Underlying().(*types.Struct); !ok {
+		return false
+	}
+
+	return selection.Kind() == types.MethodVal
+}
+
+// enclosingBody returns the body of the innermost function declaring the
+// object.
+func (fv *funcVisitor) enclosingBody(obj types.Object) *ast.BlockStmt {
+	for _, n := range fv.pathTo(obj.Pos()) {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return n.Body
+		case *ast.FuncDecl:
+			return n.Body
+		}
+	}
+
+	return nil
+}
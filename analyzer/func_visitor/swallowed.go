@@ -0,0 +1,188 @@
+package func_visitor
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkSwallowedErrors reports callbacks that always return nil although they
+// check errors of the calls they make: the errors never reach the group, which
+// is thus neither cancelled nor returns them from Wait.
+func (fv *funcVisitor) checkSwallowedErrors(funcLit *ast.FuncLit, elem *errgroupStackElement) {
+	var (
+		returns    int
+		nonNil     bool
+		errCalls   = make(map[types.Object][]*ast.CallExpr)
+		comparison []*ast.BinaryExpr
+	)
+
+	ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			returns++
+			nonNil = nonNil || len(n.Results) != 1 || !fv.isNil(n.Results[0])
+		case *ast.AssignStmt:
+			if len(n.Rhs) != 1 {
+				return true
+			}
+
+			call, ok := n.Rhs[0].(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			for _, e := range n.Lhs {
+				if ident, ok := e.(*ast.Ident); ok && ident.Name != "_" && isErrorType(fv.pass.TypesInfo.TypeOf(ident)) {
+					obj := fv.pass.TypesInfo.ObjectOf(ident)
+					errCalls[obj] = append(errCalls[obj], call)
+				}
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.EQL || n.Op == token.NEQ {
+				comparison = append(comparison, n)
+			}
+		}
+
+		return true
+	})
+
+	if returns == 0 || nonNil {
+		return
+	}
+
+	var reported []*ast.CallExpr
+
+	for _, cmp := range comparison {
+		call, errObj := fv.checkedCall(cmp, errCalls)
+		if call == nil || slices.Contains(reported, call) || fv.forwarded(errObj, cmp, funcLit) {
+			continue
+		}
+
+		reported = append(reported, call)
+
+		fv.report(RuleSwallowedError, call, nil,
+			"error of %s is checked but dropped, the callback of errgroup %q always returns nil so the group never sees it",
+			types.ExprString(call.Fun), elem.groupObj.Name())
+	}
+}
+
+// checkedCall returns the call whose error the comparison checks against nil,
+// the last one assigned to the compared variable before the comparison, along
+// with the variable.
+func (fv *funcVisitor) checkedCall(cmp *ast.BinaryExpr, errCalls map[types.Object][]*ast.CallExpr) (*ast.CallExpr, types.Object) {
+	for _, pair := range [][2]ast.Expr{{cmp.X, cmp.Y}, {cmp.Y, cmp.X}} {
+		ident, ok := ast.Unparen(pair[0]).(*ast.Ident)
+		if !ok || !fv.isNil(pair[1]) {
+			continue
+		}
+
+		obj := fv.pass.TypesInfo.Uses[ident]
+
+		var checked *ast.CallExpr
+		for _, call := range errCalls[obj] {
+			if call.Pos() < cmp.Pos() {
+				checked = call
+			}
+		}
+
+		return checked, obj
+	}
+
+	return nil, nil
+}
+
+// forwarded reports whether the error is passed on after the comparison: sent
+// on a channel, assigned, e.g. stored in a captured variable or appended to a
+// slice, or passed to a function other than a logger.
+func (fv *funcVisitor) forwarded(errObj types.Object, cmp *ast.BinaryExpr, funcLit *ast.FuncLit) bool {
+	var found bool
+
+	ast.PreorderStack(funcLit.Body, nil, func(n ast.Node, stack []ast.Node) bool {
+		if found {
+			return false
+		}
+
+		ident, ok := n.(*ast.Ident)
+		if !ok || ident.Pos() < cmp.End() || fv.pass.TypesInfo.Uses[ident] != errObj {
+			return true
+		}
+
+		for i := len(stack) - 1; i >= 0 && !found; i-- {
+			switch p := stack[i].(type) {
+			case *ast.CallExpr:
+				if contains(p.Fun, ident) {
+					return true
+				}
+
+				if !fv.isLoggerCall(p) {
+					found = true
+				}
+			case *ast.SendStmt:
+				found = contains(p.Value, ident)
+
+				return true
+			case *ast.AssignStmt:
+				found = !slices.ContainsFunc(p.Lhs, func(e ast.Expr) bool { return contains(e, ident) }) &&
+					slices.ContainsFunc(p.Lhs, func(e ast.Expr) bool { return !isBlank(e) })
+
+				return true
+			case ast.Stmt:
+				return true
+			}
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// isLoggerCall reports whether the call only prints its arguments: a function
+// of the log, log/slog or fmt packages, or a logging method such as Printf or
+// Error.
+func (fv *funcVisitor) isLoggerCall(call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(fv.pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return false
+	}
+
+	if fn.Pkg() != nil {
+		switch fn.Pkg().Path() {
+		case "log", "log/slog", "fmt":
+			return true
+		}
+	}
+
+	if fn.Signature().Recv() == nil {
+		return false
+	}
+
+	name := strings.TrimSuffix(strings.TrimSuffix(fn.Name(), "f"), "ln")
+
+	return slices.Contains([]string{"Print", "Log", "Debug", "Info", "Warn", "Error", "Fatal"}, name)
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && ident.Name == "_"
+}
+
+// isNil reports whether the expression is the predeclared nil.
+func (fv *funcVisitor) isNil(expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	_, isNil := fv.pass.TypesInfo.Uses[ident].(*types.Nil)
+
+	return isNil
+}
@@ -0,0 +1,19 @@
+package func_visitor
+
+// checkTryGoResults reports TryGo calls whose result is dropped: when the
+// group's limit is reached TryGo does not run the callback, and nobody notices.
+func (fv *funcVisitor) checkTryGoResults(g *groupInfo) {
+	if len(g.setLimitCalls) == 0 && !fv.cfg.CheckTryGoWithoutLimit {
+		return
+	}
+
+	for _, tryGo := range g.tryGoCalls {
+		if !tryGo.resultIgnored {
+			continue
+		}
+
+		fv.report(RuleTryGoResult, tryGo.call.Fun, nil,
+			"result of TryGo on errgroup %q is ignored, the callback is silently dropped when the limit is reached",
+			g.groupName)
+	}
+}
@@ -1,6 +1,7 @@
 package func_visitor
 
 import (
+	"go/ast"
 	"go/types"
 	"slices"
 )
@@ -17,6 +18,14 @@ type errgroupStackElement struct {
 	ctxObj   types.Object
 	ctxName  string
 	depth    int
+
+	info *groupInfo
+
+	// parentObj and derive are only set for contexts tracked by the
+	// derived context visitor: the context the element's one is derived
+	// from and the call deriving it.
+	parentObj types.Object
+	derive    *ast.CallExpr
 }
 
 func (s errgroupStack) Trim(depth int) errgroupStack {
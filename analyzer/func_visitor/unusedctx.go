@@ -0,0 +1,225 @@
+package func_visitor
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkUnusedContext reports derived contexts that are only ever assigned to
+// the blank identifier: the group could have been created without one.
+func (fv *funcVisitor) checkUnusedContext(g *groupInfo) {
+	if g.ctxObj == nil || g.fn == nil {
+		return
+	}
+
+	ctxIdent := declaredIdent(g.decl, g.ctxObj, fv.pass.TypesInfo)
+	if ctxIdent == nil {
+		return
+	}
+
+	var (
+		used   bool
+		blanks []*ast.AssignStmt
+	)
+
+	ast.PreorderStack(g.fn, nil, func(n ast.Node, stack []ast.Node) bool {
+		if used {
+			return false
+		}
+
+		ident, ok := n.(*ast.Ident)
+		if !ok || fv.pass.TypesInfo.Uses[ident] != g.ctxObj {
+			return true
+		}
+
+		if assign, ok := parent(stack).(*ast.AssignStmt); ok && isBlankAssignment(assign) && len(assign.Rhs) == 1 {
+			blanks = append(blanks, assign)
+
+			return true
+		}
+
+		used = true
+
+		return false
+	})
+
+	if used {
+		return
+	}
+
+	related := []analysis.RelatedInformation{
+		relatedTo(g.constructor, "errgroup %q is created here", g.groupName),
+	}
+
+	fv.reportWithFixes(RuleUnusedContext, ctxIdent, related, fv.newGroupFix(g, ctxIdent, blanks),
+		"derived context %q of errgroup %q is not used by any of its callbacks, the group does not need a context",
+		g.ctxName, g.groupName)
+}
+
+// newGroupFix suggests replacing "eg, egCtx := errgroup.WithContext(ctx)" with
+// "eg := new(errgroup.Group)" and dropping the blank assignments of egCtx. No
+// fix is offered if the rewrite would leave a variable or an import unused.
+func (fv *funcVisitor) newGroupFix(g *groupInfo, ctxIdent *ast.Ident, blanks []*ast.AssignStmt) []analysis.SuggestedFix {
+	assign, ok := g.decl.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 || assign.Rhs[0] != g.constructor {
+		return nil
+	}
+
+	sel, ok := g.constructor.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "WithContext" {
+		return nil
+	}
+
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	pkgName, ok := fv.pass.TypesInfo.Uses[pkgIdent].(*types.PkgName)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := pkgName.Imported().Scope().Lookup("Group").(*types.TypeName); !ok {
+		return nil
+	}
+
+	groupIdent, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok || groupIdent == ctxIdent {
+		return nil
+	}
+
+	for _, arg := range g.constructor.Args {
+		if !fv.usedElsewhere(arg) {
+			return nil
+		}
+	}
+
+	tok := token.ASSIGN
+	if assign.Tok == token.DEFINE && fv.pass.TypesInfo.Defs[groupIdent] != nil {
+		tok = token.DEFINE
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     assign.Pos(),
+		End:     assign.End(),
+		NewText: []byte(groupIdent.Name + " " + tok.String() + " new(" + pkgIdent.Name + ".Group)"),
+	}}
+
+	for _, blank := range blanks {
+		edits = append(edits, fv.deleteStmt(blank))
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Create the group with new(" + pkgIdent.Name + ".Group)",
+		TextEdits: edits,
+	}}
+}
+
+// usedElsewhere reports whether every local variable and import referenced by
+// the expression is also referenced outside of it, so that the expression can
+// be deleted without breaking the build.
+func (fv *funcVisitor) usedElsewhere(expr ast.Expr) bool {
+	ok := true
+
+	ast.Inspect(expr, func(n ast.Node) bool {
+		ident, isIdent := n.(*ast.Ident)
+		if !ok || !isIdent {
+			return ok
+		}
+
+		obj := fv.pass.TypesInfo.Uses[ident]
+		switch obj := obj.(type) {
+		case *types.PkgName:
+		case *types.Var:
+			// Package-level variables and parameters may stay unused.
+			if obj.Parent() == nil || obj.Parent() == obj.Pkg().Scope() || fv.isParam(obj) {
+				return true
+			}
+		default:
+			return true
+		}
+
+		for id, other := range fv.pass.TypesInfo.Uses {
+			if other == obj && (id.Pos() < expr.Pos() || id.Pos() >= expr.End()) {
+				return true
+			}
+		}
+
+		ok = false
+
+		return false
+	})
+
+	return ok
+}
+
+// isParam reports whether the variable is a parameter or a named result.
+func (fv *funcVisitor) isParam(obj types.Object) bool {
+	path := fv.pathTo(obj.Pos())
+	for i, n := range path {
+		if _, ok := n.(*ast.FieldList); ok && i+1 < len(path) {
+			_, ok := path[i+1].(*ast.FuncType)
+			return ok
+		}
+	}
+
+	return false
+}
+
+// deleteStmt returns an edit removing the statement, along with its line if
+// the line holds nothing else. A semicolon separating it from a following
+// statement on the same line is removed too.
+func (fv *funcVisitor) deleteStmt(stmt ast.Stmt) analysis.TextEdit {
+	edit := analysis.TextEdit{Pos: stmt.Pos(), End: stmt.End()}
+
+	file := fv.pass.Fset.File(stmt.Pos())
+
+	src, err := fv.pass.ReadFile(file.Name())
+	if err != nil || file.Size() != len(src) {
+		return edit
+	}
+
+	lineStart := file.LineStart(file.Line(stmt.Pos()))
+
+	lineEnd := token.Pos(file.Base() + file.Size())
+	if line := file.Line(stmt.End()); line < file.LineCount() {
+		lineEnd = file.LineStart(line + 1)
+	}
+
+	offset := func(pos token.Pos) int { return file.Offset(pos) }
+
+	before := src[offset(lineStart):offset(stmt.Pos())]
+	after := src[offset(stmt.End()):offset(lineEnd)]
+
+	if len(bytes.TrimSpace(before)) == 0 && len(bytes.TrimSpace(after)) == 0 {
+		return analysis.TextEdit{Pos: lineStart, End: lineEnd}
+	}
+
+	if rest := bytes.TrimLeft(after, " \t"); len(rest) > 0 && rest[0] == ';' {
+		skipped := len(after) - len(bytes.TrimLeft(rest[1:], " \t"))
+		edit.End += token.Pos(skipped)
+	}
+
+	return edit
+}
+
+// declaredIdent returns the identifier declaring the object in the assignment
+// or declaration statement.
+func declaredIdent(decl ast.Node, obj types.Object, typesInfo *types.Info) *ast.Ident {
+	var found *ast.Ident
+
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && found == nil && typesInfo.ObjectOf(ident) == obj {
+			found = ident
+		}
+
+		return found == nil
+	})
+
+	return found
+}
@@ -0,0 +1,213 @@
+package func_visitor
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkMissingWait reports groups that spawn goroutines but may leave their
+// function without calling Wait: the goroutines leak and their errors are lost.
+func (fv *funcVisitor) checkMissingWait(g *groupInfo) {
+	if g.fn == nil || fv.groupEscapes(g) {
+		return
+	}
+
+	for _, wait := range g.waitCalls {
+		// Deferred waits and waits in nested functions may run on any path.
+		if wait.deferred || wait.fn != g.fn {
+			return
+		}
+	}
+
+	var (
+		reassigns = assignsObject(g.groupObj, fv.pass.TypesInfo)
+		waits     = containsCall(g.waitCalls)
+		stop      = func(n ast.Node) bool { return reassigns(n) || waits(n) }
+	)
+
+	for _, spawn := range g.spawnCalls() {
+		if spawn.fn != g.fn {
+			continue
+		}
+
+		if !mayReturnAfter(fv.funcCFG(g.fn), spawn.call, stop) {
+			continue
+		}
+
+		related := []analysis.RelatedInformation{
+			relatedTo(spawn.call, "goroutine is started here"),
+		}
+
+		if len(g.waitCalls) == 0 {
+			fv.report(RuleMissingWait, g.constructor, related,
+				"Wait is never called on errgroup %q, its goroutines leak and their errors are lost",
+				g.groupName)
+		} else {
+			fv.report(RuleMissingWait, g.constructor, related,
+				"errgroup %q may be left without calling Wait, its goroutines leak and their errors are lost",
+				g.groupName)
+		}
+
+		return
+	}
+}
+
+// checkWaitErrors reports Wait calls whose error is dropped.
+func (fv *funcVisitor) checkWaitErrors(g *groupInfo) {
+	for _, wait := range g.waitCalls {
+		if !wait.resultIgnored {
+			continue
+		}
+
+		fv.report(RuleUncheckedWait, wait.call.Fun, nil,
+			"error returned by Wait on errgroup %q is ignored", g.groupName)
+	}
+}
+
+// checkWaitInCallbacks reports Wait calls made from within a callback of the
+// same group. The callback is one of the goroutines Wait waits for, so it never
+// returns.
+func (fv *funcVisitor) checkWaitInCallbacks(g *groupInfo) {
+	for _, wait := range g.waitCalls {
+		for _, cb := range g.callbacks {
+			if wait.call.Pos() < cb.funcLit.Pos() || cb.funcLit.End() < wait.call.End() {
+				continue
+			}
+
+			// A goroutine started by the callback may wait for the group.
+			if startedByGoStmt(cb.funcLit, wait.call) {
+				continue
+			}
+
+			method := cb.call.Fun.(*ast.SelectorExpr).Sel.Name // safe: callbacks are passed to methods
+
+			fv.report(RuleWaitInCallback, wait.call, []analysis.RelatedInformation{
+				relatedTo(cb.call, "the callback is passed to %s here", method),
+			}, "Wait on errgroup %q is called from one of its own callbacks, which deadlocks",
+				g.groupName)
+
+			break
+		}
+	}
+}
+
+// startedByGoStmt reports whether the node is inside a go statement within
+// the function literal.
+func startedByGoStmt(funcLit *ast.FuncLit, node ast.Node) bool {
+	var inGoStmt bool
+
+	ast.PreorderStack(funcLit.Body, nil, func(n ast.Node, stack []ast.Node) bool {
+		if n != node {
+			return n.Pos() <= node.Pos() && node.End() <= n.End()
+		}
+
+		for _, parent := range stack {
+			if _, ok := parent.(*ast.GoStmt); ok {
+				inGoStmt = true
+			}
+		}
+
+		return false
+	})
+
+	return inGoStmt
+}
+
+// checkGoAfterWait reports Go and TryGo calls that may run after Wait on a
+// group bound to a derived context: Wait cancels that context, so the new
+// callbacks would start with a context that is already done. Groups without a
+// derived context may be reused freely.
+func (fv *funcVisitor) checkGoAfterWait(g *groupInfo) {
+	if g.ctxName == "" || g.ctxName == "_" {
+		return
+	}
+
+	reassigns := assignsObject(g.groupObj, fv.pass.TypesInfo)
+
+	for _, spawn := range g.spawnCalls() {
+		if spawn.deferred {
+			continue
+		}
+
+		for _, wait := range g.waitCalls {
+			if wait.deferred || wait.fn != spawn.fn {
+				continue
+			}
+
+			if !mayFollow(fv.funcCFG(spawn.fn), wait.call, spawn.call, reassigns) {
+				continue
+			}
+
+			method := spawn.call.Fun.(*ast.SelectorExpr).Sel.Name // safe: recorded calls are method calls
+
+			fv.report(RuleGoAfterWait, spawn.call.Fun, []analysis.RelatedInformation{
+				relatedTo(wait.call, "Wait is called here"),
+			}, "%s on errgroup %q may be called after Wait, its derived context %q is already cancelled by then",
+				method, g.groupName, g.ctxName)
+
+			break
+		}
+	}
+}
+
+// groupEscapes reports whether the group variable is used within its function
+// for anything but calling its methods, e.g. it is returned, passed to another
+// function or stored elsewhere. Somebody else may wait for it then.
+func (fv *funcVisitor) groupEscapes(g *groupInfo) bool {
+	var escapes bool
+
+	ast.PreorderStack(g.fn, nil, func(n ast.Node, stack []ast.Node) bool {
+		if escapes {
+			return false
+		}
+
+		ident, ok := n.(*ast.Ident)
+		if !ok || fv.pass.TypesInfo.Uses[ident] != g.groupObj || len(stack) == 0 {
+			return true
+		}
+
+		switch parent := stack[len(stack)-1].(type) {
+		case *ast.SelectorExpr:
+			// Method calls are fine, method values are not.
+			if len(stack) >= 2 {
+				if call, ok := stack[len(stack)-2].(*ast.CallExpr); ok && call.Fun == parent {
+					return true
+				}
+			}
+		case *ast.AssignStmt:
+			if isBlankAssignment(parent) || assignsTo(parent, ident) {
+				return true
+			}
+		}
+
+		escapes = true
+
+		return false
+	})
+
+	return escapes
+}
+
+// isBlankAssignment reports whether all values of the assignment are dropped.
+func isBlankAssignment(assignStmt *ast.AssignStmt) bool {
+	for _, e := range assignStmt.Lhs {
+		if ident, ok := e.(*ast.Ident); !ok || ident.Name != "_" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// assignsTo reports whether the identifier is on the left side of the
+// assignment.
+func assignsTo(assignStmt *ast.AssignStmt, ident *ast.Ident) bool {
+	for _, e := range assignStmt.Lhs {
+		if e == ident {
+			return true
+		}
+	}
+
+	return false
+}
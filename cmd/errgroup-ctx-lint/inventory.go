@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/analyzer"
+	"github.com/m-ocean-it/errgroup-ctx-lint/analyzer/func_visitor"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+const inventoryCommand = "inventory"
+
+// runInventory implements the "inventory" subcommand: it loads the given
+// packages, runs the analyzer over them and prints every errgroup it found.
+func runInventory(args []string) int {
+	flags := flag.NewFlagSet(inventoryCommand, flag.ExitOnError)
+	pkgPaths := flags.String("pkgs", defaultPkgPaths, pkgsFlagUsage)
+	asJSON := flags.Bool("json", false, "Print the inventory as JSON instead of a table.")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s %s [-flag] [package]\n\nFlags:\n", os.Args[0], inventoryCommand)
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 1
+	}
+
+	cfg := analyzer.DefaultConfig
+	cfg.ErrgroupPackagePaths = parsePkgPaths(*pkgPaths, cfg.ErrgroupPackagePaths)
+
+	reports, err := inventory(cfg, flags.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *asJSON {
+		err = printInventoryJSON(os.Stdout, reports)
+	} else {
+		err = printInventoryTable(os.Stdout, reports)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func inventory(cfg func_visitor.Config, patterns []string) ([]func_visitor.GroupReport, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("%d errors while loading packages", n)
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer.NewAnalyzerWithConfig(cfg)}, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []func_visitor.GroupReport
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return nil, act.Err
+		}
+
+		reports = append(reports, act.Result.([]func_visitor.GroupReport)...)
+	}
+
+	return reports, nil
+}
+
+func printInventoryJSON(w io.Writer, reports []func_visitor.GroupReport) error {
+	if reports == nil {
+		reports = []func_visitor.GroupReport{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(reports)
+}
+
+func printInventoryTable(w io.Writer, reports []func_visitor.GroupReport) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "POSITION\tCONSTRUCTOR\tGROUP\tCONTEXT\tLIMIT\tGO\tTRYGO\tWAIT\tFLAGGED")
+
+	for _, r := range reports {
+		ctxName := r.DerivedContext
+		if ctxName == "" {
+			ctxName = "-"
+		}
+
+		limit := "-"
+		switch {
+		case r.SetLimit != nil:
+			limit = strconv.FormatInt(*r.SetLimit, 10)
+		case r.SetLimitCalled:
+			limit = "?"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%t\t%t\n",
+			r.Position, r.Constructor, r.Group, ctxName, limit,
+			r.GoSites, r.TryGoSites, r.WaitCalled, r.Flagged)
+	}
+
+	return tw.Flush()
+}
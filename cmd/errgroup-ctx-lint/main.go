@@ -2,33 +2,99 @@ package main
 
 import (
 	"flag"
+	"log"
+	"os"
 	"strings"
+	"sync"
 
 	"github.com/m-ocean-it/errgroup-ctx-lint/analyzer"
-	"golang.org/x/tools/go/analysis/singlechecker"
+	"github.com/m-ocean-it/errgroup-ctx-lint/analyzer/func_visitor"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+const (
+	defaultPkgPaths = "golang.org/x/sync/errgroup"
+	pkgsFlagUsage   = "Comma-separated list of packages that provide an errgroup. Use in case you're dealing with a non-standard errgroup library."
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == inventoryCommand {
+		os.Exit(runInventory(os.Args[2:]))
+	}
+
 	pkgPaths := flag.String("pkgs",
-		"golang.org/x/sync/errgroup", // Default.
-		"Comma-separated list of packages that provide an errgroup. Use in case you're dealing with a non-standard errgroup library.",
+		defaultPkgPaths, // Default.
+		pkgsFlagUsage,
+	)
+	enable := flag.String("enable", "",
+		"Comma-separated list of rules to turn on in addition to the default ones.",
+	)
+	disable := flag.String("disable", "",
+		"Comma-separated list of rules to turn off.",
+	)
+	explain := flag.String("explain", "",
+		"A file:line position. Print the errgroup stack and the classification of every context referenced on that line to stderr.",
+	)
+	aggregate := flag.Bool("aggregate", false,
+		"Emit one diagnostic per errgroup callback listing all outer contexts it references, instead of one per reference.",
+	)
+	messageTemplate := flag.String("message-template", "",
+		"A text/template replacing the text of outer context diagnostics. "+
+			"Fields: .OuterContext, .OuterContexts, .DerivedContext, .Group, .Rule, .CallbackKind.",
 	)
 
-	flag.Parse()
-
-	cfg := analyzer.DefaultConfig
+	// multichecker.Main registers a flag per analyzer and parses the command
+	// line, so the configuration is only built once the analyzers run.
+	config := sync.OnceValue(func() func_visitor.Config {
+		cfg := analyzer.DefaultConfig
+		cfg.ErrgroupPackagePaths = parsePkgPaths(*pkgPaths, cfg.ErrgroupPackagePaths)
+		cfg.Enable = parseList(*enable)
+		cfg.Disable = parseList(*disable)
+		cfg.Explain = *explain
+		cfg.AggregateByCallback = *aggregate
+		cfg.MessageTemplate = *messageTemplate
 
-	if strings.TrimSpace(*pkgPaths) != "" {
-		cfg.ErrgroupPackagePaths = []string{}
-		for p := range strings.SplitSeq(*pkgPaths, ",") {
-			cfg.ErrgroupPackagePaths = append(
-				cfg.ErrgroupPackagePaths,
-				strings.TrimSpace(p),
-			)
+		if err := cfg.Prepare(); err != nil {
+			log.Fatal(err)
 		}
+
+		return cfg
+	})
+
+	errgroupAnalyzer := analyzer.NewAnalyzerWithConfig(analyzer.DefaultConfig)
+	errgroupAnalyzer.Run = func(pass *analysis.Pass) (any, error) {
+		return analyzer.Run(config())(pass)
 	}
 
-	singlechecker.Main(
-		analyzer.NewAnalyzerWithConfig(cfg),
-	)
+	derivedAnalyzer := analyzer.NewDerivedAnalyzerWithConfig(analyzer.DefaultConfig)
+	derivedAnalyzer.Run = func(pass *analysis.Pass) (any, error) {
+		return analyzer.RunDerived(config())(pass)
+	}
+
+	multichecker.Main(errgroupAnalyzer, derivedAnalyzer)
+}
+
+// parsePkgPaths splits the value of the -pkgs flag, falling back to the
+// given defaults when the value is blank.
+func parsePkgPaths(value string, defaults []string) []string {
+	if strings.TrimSpace(value) == "" {
+		return defaults
+	}
+
+	return parseList(value)
+}
+
+// parseList splits a comma-separated flag value.
+func parseList(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		items = append(items, strings.TrimSpace(item))
+	}
+
+	return items
 }
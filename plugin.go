@@ -21,13 +21,23 @@ func New(settings any) (register.LinterPlugin, error) {
 		return nil, err
 	}
 
+	if err := s.Prepare(); err != nil {
+		return nil, err
+	}
+
 	return &Plugin{settings: s}, nil
 }
 
 func (f *Plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
-	return []*analysis.Analyzer{
+	analyzers := []*analysis.Analyzer{
 		analyzer.NewAnalyzerWithConfig(f.settings),
-	}, nil
+	}
+
+	if f.settings.RuleEnabled(func_visitor.RuleDerivedContext) {
+		analyzers = append(analyzers, analyzer.NewDerivedAnalyzerWithConfig(f.settings))
+	}
+
+	return analyzers, nil
 }
 
 func (f *Plugin) GetLoadMode() string {
@@ -0,0 +1,104 @@
+package aggregate
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func MultipleCtxArgs() {
+	ctx1 := context.Background()
+	ctx2 := context.TODO()
+	eg, egCtx := errgroup.WithContext(ctx1)
+	_ = egCtx
+	eg.Go(func() error { // want `errgroup callback should probably not reference outer contexts "ctx1", "ctx2", use the errgroup-derived context "egCtx"`
+		if err := doSmth2(ctx1, ctx2); err != nil {
+			return err
+		}
+		return doSmth2(ctx2, ctx1)
+	})
+	eg.Wait()
+}
+
+func SingleOuterContext() {
+	ctx := context.Background()
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error { // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+		<-ctx.Done()
+		<-egCtx.Done()
+		return ctx.Err()
+	})
+	eg.Wait()
+}
+
+func TripleNestedErrGroup() {
+	ctx := context.Background()
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+	eg1.Go(func() error {
+		eg2, egCtx2 := errgroup.WithContext(egCtx1)
+		eg2.Go(func() error {
+			eg3, egCtx3 := errgroup.WithContext(egCtx2)
+			_ = egCtx3
+			eg3.Go(func() error { // want `errgroup callback should probably not reference outer contexts "ctx", "egCtx1", "egCtx2", use the errgroup-derived context "egCtx3"`
+				<-ctx.Done()
+				<-egCtx1.Done()
+				<-egCtx2.Done()
+				return nil
+			})
+			return eg3.Wait()
+		})
+		return eg2.Wait()
+	})
+	eg1.Wait()
+}
+
+func HelperClosureByName() {
+	ctx1 := context.Background()
+	ctx2 := context.TODO()
+	eg, egCtx := errgroup.WithContext(ctx1)
+	_ = egCtx
+	f := func() error {
+		if err := doSmth2(ctx1, ctx2); err != nil {
+			return err
+		}
+		return doSmth2(ctx1, ctx1)
+	}
+	eg.Go(f) // want `errgroup callback should probably not reference outer contexts "ctx1", "ctx2", use the errgroup-derived context "egCtx"`
+	eg.Wait()
+}
+
+func Factory() {
+	ctx := context.Background()
+	eg, egCtx := errgroup.WithContext(ctx)
+	_ = egCtx
+	eg.Go(mk(ctx, ctx)) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	eg.Wait()
+}
+
+func Nolint() {
+	ctx := context.Background()
+	eg, egCtx := errgroup.WithContext(ctx)
+	_ = egCtx
+	eg.Go(func() error { //nolint:errgroupctx
+		return doSmth2(ctx, ctx)
+	})
+	eg.Go(func() error {
+		return doSmth2(ctx, ctx) //nolint:errgroupctx
+	})
+	eg.Wait()
+}
+
+func Correct() {
+	ctx := context.Background()
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return doSmth2(egCtx, egCtx)
+	})
+	eg.Wait()
+}
+
+func doSmth2(_ context.Context, _ context.Context) error { return nil }
+
+func mk(ctx1, ctx2 context.Context) func() error {
+	return func() error { return doSmth2(ctx1, ctx2) }
+}
@@ -0,0 +1,179 @@
+package blockingchannel
+
+import (
+	"context"
+	"time"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func Send(items []int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	out := make(chan int)
+	eg.Go(func() error {
+		for _, item := range items {
+			out <- item // want `send on channel "out" in errgroup callback may block forever, select on egCtx.Done\(\) as well`
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func Receive(in chan int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	eg.Go(func() error {
+		v := <-in // want `receive from channel "in" in errgroup callback may block forever, select on egCtx.Done\(\) as well`
+		_ = v
+		return nil
+	})
+	eg.Wait()
+}
+
+func Range(in <-chan int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	eg.Go(func() error {
+		for v := range in { // want `range over channel "in" in errgroup callback may block forever, select on egCtx.Done\(\) as well`
+			_ = v
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func SelectWithoutContext(a, b chan int) {
+	ctx := context.Background()
+	eg, egCtx := errgroup.WithContext(ctx)
+	_ = egCtx
+	eg.Go(func() error {
+		select {
+		case <-a: // want `receive from channel "a" in errgroup callback may block forever, select on egCtx.Done\(\) as well`
+		case b <- 1: // want `send on channel "b" in errgroup callback may block forever, select on egCtx.Done\(\) as well`
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func BlockingInsideSelectCase(a, b chan int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		select {
+		case <-egCtx.Done():
+			return egCtx.Err()
+		case v := <-a:
+			b <- v // want `send on channel "b" in errgroup callback may block forever, select on egCtx.Done\(\) as well`
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_SelectCorrect() {
+	ctx := context.Background()
+	eg, egCtx := errgroup.WithContext(ctx)
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	eg.Go(func() error {
+		for {
+			select {
+			case <-egCtx.Done():
+				return egCtx.Err()
+			case <-t.C:
+			}
+		}
+	})
+	eg.Wait()
+}
+
+func Neg_SendInSelect(items []int, out chan<- int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		for _, item := range items {
+			select {
+			case out <- item:
+			case <-egCtx.Done():
+				return egCtx.Err()
+			}
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_SelectOnDerivedInsideCallback(in chan int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		ctx, cancel := context.WithTimeout(egCtx, time.Second)
+		defer cancel()
+		select {
+		case <-in:
+		case <-ctx.Done():
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_SelectDefault(out chan int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	eg.Go(func() error {
+		select {
+		case out <- 1:
+		default:
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_Buffered(items []int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	out := make(chan int, len(items))
+	eg.Go(func() error {
+		for _, item := range items {
+			out <- item
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_DoneAndTimers() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	timer := time.NewTimer(time.Second)
+	eg.Go(func() error {
+		<-egCtx.Done()
+		<-time.After(time.Millisecond)
+		<-timer.C
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_Goroutine(out chan int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	eg.Go(func() error {
+		go func() {
+			out <- 1
+		}()
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_PlainGroup(out chan int) {
+	eg := errgroup.New()
+	eg.Go(func() error {
+		out <- 1
+		return nil
+	})
+	eg.Wait()
+}
@@ -0,0 +1,130 @@
+package derivedctx
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+func GoStatement(ctx context.Context) {
+	ctx2, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		use(ctx) // want `goroutine should probably not reference parent context "ctx", use the derived context "ctx2"`
+		use(ctx2)
+	}()
+}
+
+func GoCall(ctx context.Context) {
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	go use(ctx) // want `goroutine should probably not reference parent context "ctx", use the derived context "tctx"`
+	go use(tctx)
+}
+
+func WaitGroupGo(ctx context.Context) {
+	sigCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		<-ctx.Done() // want `goroutine should probably not reference parent context "ctx", use the derived context "sigCtx"`
+	})
+	wg.Go(func() {
+		<-sigCtx.Done()
+	})
+	wg.Wait()
+}
+
+func Chain(ctx context.Context) {
+	ctx2, cancel2 := context.WithCancel(ctx)
+	defer cancel2()
+
+	ctx3, cancel3 := context.WithTimeout(ctx2, time.Second)
+	defer cancel3()
+
+	go func() {
+		use(ctx)  // want `goroutine should probably not reference parent context "ctx", use the derived context "ctx3"`
+		use(ctx2) // want `goroutine should probably not reference parent context "ctx2", use the derived context "ctx3"`
+		use(ctx3)
+	}()
+}
+
+func NestedGoroutine(ctx context.Context) {
+	ctx2, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		go func() {
+			use(ctx) // want `goroutine should probably not reference parent context "ctx", use the derived context "ctx2"`
+		}()
+		use(ctx2)
+	}()
+}
+
+// --- Negative ---
+
+func Neg_BeforeDerive(ctx context.Context) {
+	go use(ctx)
+
+	ctx2, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go use(ctx2)
+}
+
+func Neg_OtherScope(ctx context.Context, cond bool) {
+	if cond {
+		ctx2, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		go use(ctx2)
+	}
+
+	go use(ctx)
+}
+
+func Neg_Shadowed(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go use(ctx)
+}
+
+func Neg_DiscardedContext(ctx context.Context) {
+	_, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go use(ctx)
+}
+
+func Neg_NotCancellable(ctx context.Context) {
+	vctx := context.WithValue(ctx, key{}, 1)
+
+	go use(ctx)
+	go use(vctx)
+}
+
+func Neg_Nolint(ctx context.Context) {
+	ctx2, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go use(ctx) //nolint:derivedctx
+	go use(ctx2)
+}
+
+func Neg_NotInGoroutine(ctx context.Context) {
+	ctx2, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	use(ctx)
+	use(ctx2)
+}
+
+type key struct{}
+
+func use(_ context.Context) {}
@@ -0,0 +1,120 @@
+package detachedroot
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+var pkgCtx = context.Background()
+
+func Background(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(context.Background()) // want `errgroup "eg" is derived from context.Background\(\) although parameter "ctx" is available, it is not cancelled along with the caller`
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func TODO(_ int, reqCtx context.Context) error {
+	var eg, egCtx = errgroup.WithContext(context.TODO()) // want `errgroup "eg" is derived from context.TODO\(\) although parameter "reqCtx" is available, it is not cancelled along with the caller`
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func PackageLevel(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(pkgCtx) // want `errgroup "eg" is derived from package-level context "pkgCtx" although parameter "ctx" is available, it is not cancelled along with the caller`
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func InClosure(ctx context.Context) func() error {
+	return func() error {
+		eg, _ := errgroup.WithContext(context.Background()) // want `errgroup "eg" is derived from context.Background\(\) although parameter "ctx" is available, it is not cancelled along with the caller`
+		eg.Go(func() error { return nil })
+
+		return eg.Wait()
+	}
+}
+
+type server struct {
+	ctx context.Context
+}
+
+func (s *server) Run() error {
+	eg, egCtx := errgroup.WithContext(context.Background()) // want `errgroup "eg" is derived from context.Background\(\) although receiver field "s.ctx" is available, it is not cancelled along with the caller`
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_NoContextInScope() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func Neg_DerivedFromParameter(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func Neg_BlankParameter(_ context.Context) error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func Neg_PlainGroup(ctx context.Context) error {
+	eg := errgroup.New()
+	eg.Go(func() error {
+		return doSmth(ctx)
+	})
+
+	return eg.Wait()
+}
+
+type worker struct {
+	n int
+}
+
+func (w worker) Run() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func Neg_Nolint(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(context.Background()) //nolint:errgroupctx
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func doSmth(_ context.Context) error { return nil }
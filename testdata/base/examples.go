@@ -1336,6 +1336,24 @@ func StructLiteral() {
 	eg.Wait()
 }
 
+// Keyed struct literal: the field name is not a reference to a context, only
+// the value is.
+func KeyedStructLiteral() {
+	ctx := context.Background()
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		s := ctxHolder{ctx: egCtx}
+		_ = s
+		return nil
+	})
+	eg.Go(func() error {
+		s := ctxHolder{ctx: ctx} // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+		_ = s
+		return nil
+	})
+	eg.Wait()
+}
+
 // Context used as a map key.
 func MapKey() {
 	ctx := context.Background()
@@ -1722,7 +1740,7 @@ func TryGoWithSetLimit() {
 	eg, egCtx := errgroup.WithContext(ctx)
 	_ = egCtx
 	eg.SetLimit(3)
-	eg.TryGo(func() error {
+	eg.TryGo(func() error { // want `result of TryGo on errgroup "eg" is ignored, the callback is silently dropped when the limit is reached`
 		<-ctx.Done() // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
 		return nil
 	})
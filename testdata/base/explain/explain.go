@@ -0,0 +1,22 @@
+package explain
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func Explained() error {
+	ctx := context.Background()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		localCtx := context.WithoutCancel(egCtx)
+		return doSmth2(ctx, egCtx, localCtx) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	})
+
+	return eg.Wait()
+}
+
+func doSmth2(_, _, _ context.Context) error { return nil }
@@ -0,0 +1,60 @@
+package factory
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/factory/workers"
+)
+
+var defaultCtx = context.Background()
+
+func makeWorker(ctx context.Context, item int) func() error {
+	return func() error { return doSmth(ctx, item) }
+}
+
+func makeDefaultWorker(item int) func() error { // want makeDefaultWorker:"factory of callbacks referencing defaultCtx"
+	return func() error { return doSmth(defaultCtx, item) }
+}
+
+func OuterContextArgument(ctx context.Context, items []int) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, item := range items {
+		eg.Go(makeWorker(ctx, item))                 // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+		eg.TryGo(workers.MakeWithContext(ctx, item)) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+		eg.Go(makeWorker(egCtx, item))
+	}
+	return eg.Wait()
+}
+
+func PackageLevelContext(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(makeDefaultWorker(1)) // want `errgroup callback should probably not reference outer context "defaultCtx", use the errgroup-derived context "egCtx"`
+	eg.Go(workers.Make(2))      // want `errgroup callback should probably not reference outer context "AppCtx", use the errgroup-derived context "egCtx"`
+	eg.Go(workers.MakeWithContext(egCtx, 3))
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_DerivedInsideArgument(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(makeWorker(context.WithoutCancel(egCtx), 1))
+	return eg.Wait()
+}
+
+func Neg_NoDerivedContext(ctx context.Context) error {
+	eg := errgroup.New()
+	eg.Go(makeWorker(ctx, 1))
+	eg.Go(makeDefaultWorker(2))
+	return eg.Wait()
+}
+
+func Neg_Nolint(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	_ = egCtx
+	eg.Go(makeDefaultWorker(1)) //nolint:errgroupctx
+	return eg.Wait()
+}
+
+func doSmth(context.Context, int) error { return nil }
@@ -0,0 +1,18 @@
+package workers
+
+import "context"
+
+var AppCtx = context.Background()
+
+// Make returns a callback bound to the application context.
+func Make(item int) func() error {
+	run := func() error { return process(AppCtx, item) }
+	return run
+}
+
+// MakeWithContext returns a callback bound to the given context.
+func MakeWithContext(ctx context.Context, item int) func() error {
+	return func() error { return process(ctx, item) }
+}
+
+func process(context.Context, int) error { return nil }
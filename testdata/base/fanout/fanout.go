@@ -0,0 +1,122 @@
+package fanout
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func RangeOverSlice(items []int) error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	for _, item := range items {
+		eg.Go(func() error { // want `errgroup "eg" starts a goroutine per loop iteration without a limit, call SetLimit before the loop`
+			return process(egCtx, item)
+		})
+	}
+	return eg.Wait()
+}
+
+func RangeOverMap(items map[string]int) error {
+	eg := errgroup.New()
+	for range items {
+		eg.Go(work) // want `errgroup "eg" starts a goroutine per loop iteration without a limit, call SetLimit before the loop`
+	}
+	return eg.Wait()
+}
+
+func CountedLoop(n int) error {
+	eg := errgroup.New()
+	for i := 0; i < n; i++ {
+		if i%2 == 0 {
+			eg.Go(work) // want `errgroup "eg" starts a goroutine per loop iteration without a limit, call SetLimit before the loop`
+		}
+	}
+	return eg.Wait()
+}
+
+func ChannelLoop(ch <-chan int) error {
+	eg := errgroup.New()
+	for range ch {
+		eg.Go(work) // want `errgroup "eg" starts a goroutine per loop iteration without a limit, call SetLimit before the loop`
+	}
+	return eg.Wait()
+}
+
+func LargeArray(items [1000]int) error {
+	eg := errgroup.New()
+	for range items {
+		eg.Go(work) // want `errgroup "eg" starts a goroutine per loop iteration without a limit, call SetLimit before the loop`
+	}
+	return eg.Wait()
+}
+
+func LimitAfterLoop(items []int) error {
+	eg := errgroup.New()
+	for range items {
+		eg.Go(work) // want `errgroup "eg" starts a goroutine per loop iteration without a limit, call SetLimit before the loop`
+	}
+	eg.SetLimit(10) // want `SetLimit on errgroup "eg" may be called after Go, it panics while goroutines are active`
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_Limited(items []int) error {
+	eg := errgroup.New()
+	eg.SetLimit(10)
+	for range items {
+		eg.Go(work)
+	}
+	return eg.Wait()
+}
+
+func Neg_SmallConstantLoop() error {
+	eg := errgroup.New()
+	for i := 0; i < 5; i++ {
+		eg.Go(work)
+	}
+	for range 3 {
+		eg.Go(work)
+	}
+	return eg.Wait()
+}
+
+func Neg_SmallArray(items [4]int) error {
+	eg := errgroup.New()
+	for range &items {
+		eg.Go(work)
+	}
+	return eg.Wait()
+}
+
+func Neg_GroupPerIteration(items []int) error {
+	for range items {
+		eg := errgroup.New()
+		eg.Go(work)
+		if err := eg.Wait(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Neg_TryGo(items []int) error {
+	eg := errgroup.New()
+	for range items {
+		if !eg.TryGo(work) {
+			break
+		}
+	}
+	return eg.Wait()
+}
+
+func Neg_NotInLoop() error {
+	eg := errgroup.New()
+	eg.Go(work)
+	eg.Go(work)
+	return eg.Wait()
+}
+
+func process(_ context.Context, _ int) error { return nil }
+
+func work() error { return nil }
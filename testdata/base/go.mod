@@ -1,3 +1,3 @@
 module github.com/m-ocean-it/errgroup-ctx-lint/testdata/base
 
-go 1.24.5
+go 1.25
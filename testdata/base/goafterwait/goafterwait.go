@@ -0,0 +1,100 @@
+package goafterwait
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func GoAfterWait() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		<-egCtx.Done()
+		return nil
+	})
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	eg.Go(func() error { // want `Go on errgroup "eg" may be called after Wait, its derived context "egCtx" is already cancelled by then`
+		<-egCtx.Done()
+		return nil
+	})
+	return eg.Wait()
+}
+
+func TryGoAfterWait() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = eg.Wait()
+	_ = eg.TryGo(func() error { // want `TryGo on errgroup "eg" may be called after Wait, its derived context "egCtx" is already cancelled by then`
+		<-egCtx.Done()
+		return nil
+	})
+}
+
+func GoAfterWaitInLoop(items []int) error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	for range items {
+		eg.Go(func() error { // want `Go on errgroup "eg" may be called after Wait, its derived context "egCtx" is already cancelled by then`
+			<-egCtx.Done()
+			return nil
+		})
+		if err := eg.Wait(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- Negative ---
+
+func Neg_NewGroupReused() error {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	eg.Go(func() error { return nil })
+	return eg.Wait()
+}
+
+func Neg_DiscardedContext() error {
+	eg, _ := errgroup.WithContext(context.Background())
+	_ = eg.Wait()
+	eg.Go(func() error { return nil })
+	return eg.Wait()
+}
+
+func Neg_GroupPerIteration(items []int) error {
+	for range items {
+		eg, egCtx := errgroup.WithContext(context.Background())
+		eg.Go(func() error {
+			<-egCtx.Done()
+			return nil
+		})
+		if err := eg.Wait(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Neg_Reassigned() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	_ = eg.Wait()
+	eg, egCtx = errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		<-egCtx.Done()
+		return nil
+	})
+	return eg.Wait()
+}
+
+func Neg_DeferredWait() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	defer func() { _ = eg.Wait() }()
+	eg.Go(func() error {
+		<-egCtx.Done()
+		return nil
+	})
+}
@@ -0,0 +1,159 @@
+package handrolled
+
+import (
+	"context"
+	"sync"
+)
+
+func HandRolled(parent context.Context, items []int) error {
+	ctx, cancel := context.WithCancel(parent) // want `sync.WaitGroup, an error channel and context.WithCancel emulate an errgroup, use github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup.WithContext instead`
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(items))
+
+	for _, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := process(ctx, item); err != nil {
+				errCh <- err
+				cancel()
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	return <-errCh
+}
+
+func ParentInGoroutine(parent context.Context) error {
+	ctx, cancel := context.WithCancelCause(parent) // want `sync.WaitGroup, an error channel and context.WithCancelCause emulate an errgroup, use github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup.WithContext instead`
+	defer cancel(nil)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := process(parent, 1); err != nil { // want `goroutine should probably not reference the parent context "parent", use the cancellable context "ctx"`
+			errs <- err
+			cancel(err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := process(ctx, 2); err != nil {
+			errs <- err
+			cancel(err)
+		}
+	}()
+
+	wg.Wait()
+
+	return nil
+}
+
+func ParentInHelperClosure(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent) // want `sync.WaitGroup, an error channel and context.WithCancel emulate an errgroup, use github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup.WithContext instead`
+	defer cancel()
+
+	fetch := func() error { return process(parent, 3) }
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 1)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := fetch(); err != nil { // want `goroutine should probably not reference the parent context "parent", use the cancellable context "ctx"`
+			errs <- err
+			cancel()
+		}
+		_ = process(ctx, 3)
+	}()
+
+	wg.Wait()
+
+	return nil
+}
+
+type worker struct {
+	ctx context.Context
+}
+
+func (w *worker) Run() error { return process(w.ctx, 4) }
+
+func ParentInStructValue(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent) // want `sync.WaitGroup, an error channel and context.WithCancel emulate an errgroup, use github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup.WithContext instead`
+	defer cancel()
+
+	w := &worker{ctx: parent}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 1)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := w.Run(); err != nil { // want `goroutine should probably not reference the parent context "parent", use the cancellable context "ctx"`
+			errs <- err
+			cancel()
+		}
+		_ = process(ctx, 4)
+	}()
+
+	wg.Wait()
+
+	return nil
+}
+
+// --- Negative ---
+
+func Neg_NoErrorChannel(parent context.Context) {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = process(ctx, 1)
+		cancel()
+	}()
+	wg.Wait()
+}
+
+func Neg_NoWaitGroup(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- process(ctx, 1)
+		cancel()
+	}()
+
+	return <-errCh
+}
+
+func Neg_NoCancel(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, 1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errCh <- process(ctx, 1)
+	}()
+	wg.Wait()
+
+	return <-errCh
+}
+
+func process(_ context.Context, _ int) error { return nil }
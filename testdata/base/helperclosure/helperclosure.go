@@ -0,0 +1,110 @@
+package helperclosure
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func CalledFromCallback(ctx context.Context) error {
+	fetch := func() error { return doSmth(ctx) }
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := doSmth(egCtx); err != nil {
+			return err
+		}
+		return fetch() // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	})
+	return eg.Wait()
+}
+
+func PassedByName(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	fetch := func() error { return doSmth(ctx) }
+	eg.Go(fetch) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	eg.TryGo(func() error { return doSmth(egCtx) })
+	return eg.Wait()
+}
+
+func ThroughIntermediateClosure(ctx context.Context) error {
+	var (
+		fetch = func() error { return doSmth(ctx) }
+		retry = func() error {
+			if err := fetch(); err != nil {
+				return fetch()
+			}
+			return nil
+		}
+	)
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		_ = egCtx
+		return retry() // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	})
+	return eg.Wait()
+}
+
+func CapturedTwice(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	_ = egCtx
+	fetch := func() error {
+		if err := doSmth(ctx); err != nil {
+			return err
+		}
+		return doSmth(ctx)
+	}
+	eg.Go(fetch)                           // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	eg.Go(func() error { return fetch() }) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_UsesDerivedContext(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	fetch := func() error { return doSmth(egCtx) }
+	eg.Go(fetch)
+	eg.Go(func() error { return fetch() })
+	return eg.Wait()
+}
+
+func Neg_ContextParameter(ctx context.Context) error {
+	fetch := func(ctx context.Context) error { return doSmth(ctx) }
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error { return fetch(egCtx) })
+	return eg.Wait()
+}
+
+func Neg_DeclaredInsideCallback(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		fetch := func() error { return doSmth(egCtx) }
+		return fetch()
+	})
+	return eg.Wait()
+}
+
+func Neg_Recursive(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	var walk func(n int) error
+	walk = func(n int) error {
+		if n == 0 {
+			return doSmth(egCtx)
+		}
+		return walk(n - 1)
+	}
+	eg.Go(func() error { return walk(3) })
+	return eg.Wait()
+}
+
+func Neg_NoDerivedContext(ctx context.Context) error {
+	eg := errgroup.New()
+	fetch := func() error { return doSmth(ctx) }
+	eg.Go(fetch)
+	return eg.Wait()
+}
+
+func doSmth(context.Context) error { return nil }
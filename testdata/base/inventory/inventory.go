@@ -0,0 +1,46 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func WithContextAndLimit() error {
+	ctx := context.Background()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(2)
+
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	eg.TryGo(func() error { // want `result of TryGo on errgroup "eg" is ignored, the callback is silently dropped when the limit is reached`
+		return doSmth(ctx) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	})
+
+	return eg.Wait()
+}
+
+func DiscardedContext(n int) {
+	ctx := context.Background()
+
+	eg, _ := errgroup.WithContext(ctx)
+	eg.SetLimit(n)
+
+	eg.Go(func() error {
+		return doSmth(ctx)
+	})
+}
+
+func PlainGroup() error {
+	eg := errgroup.New()
+
+	eg.Go(func() error { return nil })
+	eg.Go(func() error { return nil })
+
+	return eg.Wait()
+}
+
+func doSmth(_ context.Context) error { return nil }
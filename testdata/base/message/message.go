@@ -0,0 +1,24 @@
+package message
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func Templated() {
+	ctx := context.Background()
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		return doSmth(ctx) // want `\[outer-context\] group.Go callback uses "ctx" instead of "groupCtx", see go/style#errgroup`
+	})
+	group.TryGo(func() error {
+		return doSmth(ctx) // want `\[outer-context\] group.TryGo callback uses "ctx" instead of "groupCtx", see go/style#errgroup`
+	})
+	group.Go(func() error {
+		return doSmth(groupCtx)
+	})
+	group.Wait()
+}
+
+func doSmth(_ context.Context) error { return nil }
@@ -0,0 +1,185 @@
+package nestedgroup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func OuterParent(ctx context.Context) error {
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+	_ = egCtx1
+
+	eg1.Go(func() error {
+		eg2, egCtx2 := errgroup.WithContext(ctx) // want `nested errgroup "eg2" is derived from outer context "ctx" instead of "egCtx1", the context of the enclosing errgroup "eg1", so it is not cancelled along with it`
+		eg2.Go(func() error {
+			return doSmth(egCtx2)
+		})
+		return eg2.Wait()
+	})
+
+	return eg1.Wait()
+}
+
+func BackgroundParent(ctx context.Context) error {
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+	_ = egCtx1
+
+	eg1.TryGo(func() error {
+		var eg2, egCtx2 = errgroup.WithContext(context.Background()) // want `nested errgroup "eg2" is derived from context.Background\(\) instead of "egCtx1", the context of the enclosing errgroup "eg1", so it is not cancelled along with it`
+		eg2.Go(func() error {
+			return doSmth(egCtx2)
+		})
+		return eg2.Wait()
+	})
+
+	return eg1.Wait()
+}
+
+func TODOParentInGoroutine(ctx context.Context) error {
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+	_ = egCtx1
+
+	eg1.Go(func() error {
+		done := make(chan error, 1)
+		go func() {
+			eg2, _ := errgroup.WithContext(context.TODO()) // want `nested errgroup "eg2" is derived from context.TODO\(\) instead of "egCtx1", the context of the enclosing errgroup "eg1", so it is not cancelled along with it`
+			eg2.Go(func() error { return nil })
+			done <- eg2.Wait()
+		}()
+		return <-done
+	})
+
+	return eg1.Wait()
+}
+
+func InnermostGroup(ctx context.Context) error {
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+
+	eg1.Go(func() error {
+		eg2, egCtx2 := errgroup.WithContext(egCtx1)
+		_ = egCtx2
+		eg2.Go(func() error {
+			eg3, egCtx3 := errgroup.WithContext(egCtx1) // want `nested errgroup "eg3" is derived from outer context "egCtx1" instead of "egCtx2", the context of the enclosing errgroup "eg2", so it is not cancelled along with it`
+			eg3.Go(func() error {
+				return doSmth(egCtx3)
+			})
+			return eg3.Wait()
+		})
+		return eg2.Wait()
+	})
+
+	return eg1.Wait()
+}
+
+// Constructors whose group is not assigned to a variable are not seen by the
+// nested-group rule, their parent is reported as an outer context instead.
+func UnnamedGroup(ctx context.Context) error {
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+
+	eg1.Go(func() error {
+		_, c := errgroup.WithContext(ctx) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx1"`
+		_ = c
+		return doSmth(egCtx1)
+	})
+
+	eg1.Go(func() error {
+		return run(errgroup.WithContext(ctx)) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx1"`
+	})
+
+	return eg1.Wait()
+}
+
+// Groups created in a spawner closure outside of any errgroup callback are not
+// nested, their parent is reported as an outer context.
+func InSpawnerClosure(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	_ = egCtx
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		eg2, ctx2 := errgroup.WithContext(ctx) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+		eg2.Go(func() error { return doSmth(ctx2) })
+		_ = eg2.Wait()
+	})
+	wg.Wait()
+
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_DerivedParent(ctx context.Context) error {
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+
+	eg1.Go(func() error {
+		eg2, egCtx2 := errgroup.WithContext(egCtx1)
+		eg2.Go(func() error {
+			return doSmth(egCtx2)
+		})
+		return eg2.Wait()
+	})
+
+	return eg1.Wait()
+}
+
+func Neg_ParentDerivedInCallback(ctx context.Context) error {
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+
+	eg1.Go(func() error {
+		tctx, cancel := context.WithTimeout(egCtx1, time.Second)
+		defer cancel()
+
+		eg2, egCtx2 := errgroup.WithContext(tctx)
+		eg2.Go(func() error {
+			return doSmth(egCtx2)
+		})
+		return eg2.Wait()
+	})
+
+	return eg1.Wait()
+}
+
+func Neg_EnclosingWithoutContext(ctx context.Context) error {
+	eg1 := errgroup.New()
+
+	eg1.Go(func() error {
+		eg2, egCtx2 := errgroup.WithContext(ctx)
+		eg2.Go(func() error {
+			return doSmth(egCtx2)
+		})
+		return eg2.Wait()
+	})
+
+	return eg1.Wait()
+}
+
+func Neg_NotNested() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func Neg_Nolint(ctx context.Context) error {
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+	_ = egCtx1
+
+	eg1.Go(func() error {
+		eg2, egCtx2 := errgroup.WithContext(ctx) //nolint:errgroupctx
+		eg2.Go(func() error {
+			return doSmth(egCtx2)
+		})
+		return eg2.Wait()
+	})
+
+	return eg1.Wait()
+}
+
+func doSmth(_ context.Context) error { return nil }
+
+func run(eg *errgroup.Group, _ context.Context) error { return eg.Wait() }
@@ -0,0 +1,22 @@
+package related
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func OuterContext() error {
+	ctx := context.Background()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	_ = egCtx
+
+	eg.Go(func() error {
+		return doSmth(ctx) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	})
+
+	return eg.Wait()
+}
+
+func doSmth(_ context.Context) error { return nil }
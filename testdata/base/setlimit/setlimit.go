@@ -0,0 +1,134 @@
+package setlimit
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func SetLimitAfterGo() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		<-egCtx.Done()
+		return nil
+	})
+	eg.SetLimit(2) // want `SetLimit on errgroup "eg" may be called after Go, it panics while goroutines are active`
+	eg.Wait()
+}
+
+func SetLimitAfterTryGo() {
+	eg := errgroup.New()
+	_ = eg.TryGo(func() error { return nil }) // want `result of TryGo on errgroup "eg" is ignored, the callback is silently dropped when the limit is reached`
+	eg.SetLimit(2)                            // want `SetLimit on errgroup "eg" may be called after TryGo, it panics while goroutines are active`
+	eg.Wait()
+}
+
+func SetLimitInLoop(items []int) {
+	eg := errgroup.New()
+	for range items {
+		eg.SetLimit(2) // want `SetLimit on errgroup "eg" may be called after Go, it panics while goroutines are active`
+		eg.Go(func() error { return nil })
+	}
+	eg.Wait()
+}
+
+func SetLimitInBranchAfterGo(cond bool) {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	if cond {
+		eg.SetLimit(2) // want `SetLimit on errgroup "eg" may be called after Go, it panics while goroutines are active`
+	}
+	eg.Wait()
+}
+
+func SetLimitZero() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.SetLimit(0) // want `SetLimit\(0\) on errgroup "eg" makes every Go call block forever and every TryGo call fail`
+	eg.Go(func() error {
+		<-egCtx.Done()
+		return nil
+	})
+	eg.Wait()
+}
+
+const noWorkers = 0
+
+func SetLimitZeroConst() {
+	eg := errgroup.New()
+	eg.SetLimit(noWorkers) // want `SetLimit\(0\) on errgroup "eg" makes every Go call block forever and every TryGo call fail`
+	eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_SetLimitBeforeGo() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.SetLimit(2)
+	eg.Go(func() error {
+		<-egCtx.Done()
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_SetLimitNegative() {
+	eg := errgroup.New()
+	eg.SetLimit(-1)
+	eg.Go(func() error { return nil })
+	eg.Wait()
+}
+
+func Neg_GroupPerIteration(items []int) {
+	for range items {
+		eg := errgroup.New()
+		eg.SetLimit(2)
+		eg.Go(func() error { return nil })
+		eg.Wait()
+	}
+}
+
+func Neg_GoInOtherBranch(cond bool) {
+	eg := errgroup.New()
+	if cond {
+		eg.Go(func() error { return nil })
+	} else {
+		eg.SetLimit(2)
+	}
+	eg.Wait()
+}
+
+func Neg_GoInsideCallback() {
+	eg := errgroup.New()
+	eg.SetLimit(2)
+	eg.Go(func() error {
+		eg.Go(func() error { return nil })
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_OtherGroup() {
+	eg1 := errgroup.New()
+	eg2 := errgroup.New()
+	eg1.Go(func() error { return nil })
+	eg2.SetLimit(2)
+	eg2.Go(func() error { return nil })
+	eg1.Wait()
+	eg2.Wait()
+}
+
+func Neg_Nolint() {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	eg.SetLimit(2) //nolint:errgroupctx
+	eg.Wait()
+}
+
+func Neg_SetLimitAfterWait() {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	eg.Wait()
+	eg.SetLimit(2)
+	eg.Go(func() error { return nil })
+	eg.Wait()
+}
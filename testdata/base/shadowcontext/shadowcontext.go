@@ -0,0 +1,120 @@
+package shadowcontext
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func Param(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx) // want `derived context "egCtx" of errgroup "eg" should shadow its parent context "ctx", so that the parent cannot be referenced by mistake`
+
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	eg.Go(func() error {
+		<-egCtx.Done()
+		return nil
+	})
+
+	return eg.Wait()
+}
+
+func SameScope() error {
+	ctx := context.Background()
+
+	eg, egCtx := errgroup.WithContext(ctx) // want `derived context "egCtx" of errgroup "eg" should shadow its parent context "ctx", so that the parent cannot be referenced by mistake`
+
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func ParentUsedLater(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx) // want `derived context "egCtx" of errgroup "eg" should shadow its parent context "ctx", so that the parent cannot be referenced by mistake`
+
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	return doSmth(ctx)
+}
+
+func NameTakenInside(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx) // want `derived context "egCtx" of errgroup "eg" should shadow its parent context "ctx", so that the parent cannot be referenced by mistake`
+
+	eg.Go(func() error {
+		ctx := context.WithoutCancel(egCtx)
+		return doSmth(egCtx, ctx)
+	})
+
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func CapturedBeforeConstructor(ctx context.Context) error {
+	cleanup := func() { _ = doSmth(ctx) }
+
+	eg, egCtx := errgroup.WithContext(ctx) // want `derived context "egCtx" of errgroup "eg" should shadow its parent context "ctx", so that the parent cannot be referenced by mistake`
+
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	err := eg.Wait()
+	cleanup()
+
+	return err
+}
+
+func DeferredBeforeConstructor(ctx context.Context) error {
+	defer doSmth(ctx)
+
+	eg, egCtx := errgroup.WithContext(ctx) // want `derived context "egCtx" of errgroup "eg" should shadow its parent context "ctx", so that the parent cannot be referenced by mistake`
+
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func Neg_Shadowed(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		return doSmth(ctx)
+	})
+
+	return eg.Wait()
+}
+
+func Neg_NotAnIdentifier() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func Neg_Discarded(ctx context.Context) error {
+	eg, _ := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		return nil
+	})
+
+	return eg.Wait()
+}
+
+func doSmth(_ ...context.Context) error { return nil }
@@ -0,0 +1,102 @@
+package spawners
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func WaitGroupGo(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		use(ctx) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	})
+	wg.Go(func() {
+		use(egCtx)
+	})
+	wg.Wait()
+
+	return eg.Wait()
+}
+
+func InsideCallback(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		tctx, cancel := context.WithTimeout(egCtx, time.Second)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			use(ctx) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+			use(tctx)
+		})
+		wg.Wait()
+
+		return nil
+	})
+
+	return eg.Wait()
+}
+
+func InnermostGroup(ctx context.Context) error {
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+
+	eg1.Go(func() error {
+		eg2, egCtx2 := errgroup.WithContext(egCtx1)
+		_ = egCtx2
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			use(egCtx1) // want `errgroup callback should probably not reference outer context "egCtx1", use the errgroup-derived context "egCtx2"`
+		})
+		wg.Wait()
+
+		return eg2.Wait()
+	})
+
+	return eg1.Wait()
+}
+
+type pool struct{}
+
+func (p *pool) Submit(_ string, task func()) { go task() }
+
+func CustomSpawner(ctx context.Context, p *pool) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	_ = egCtx
+
+	p.Submit("job", func() {
+		use(ctx) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	})
+
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_NoGroup(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		use(ctx)
+	})
+	wg.Wait()
+}
+
+func Neg_PlainGroup(ctx context.Context) error {
+	eg := errgroup.New()
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		use(ctx)
+	})
+	wg.Wait()
+
+	return eg.Wait()
+}
+
+func use(_ context.Context) {}
@@ -0,0 +1,97 @@
+package structcapture
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+type worker struct {
+	ctx  context.Context
+	item int
+}
+
+func (w *worker) Run() error { return doSmth(w.ctx) }
+
+type pair struct {
+	ctx context.Context
+	n   int
+}
+
+func (p pair) Run() error { return doSmth(p.ctx) }
+
+func MethodCall(ctx context.Context) error {
+	w := &worker{ctx: ctx, item: 1}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		_ = egCtx
+		return w.Run() // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	})
+	return eg.Wait()
+}
+
+func MethodValue(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	_ = egCtx
+	p := pair{ctx, 1}
+	eg.Go(p.Run) // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	return eg.Wait()
+}
+
+func FieldStore(ctx context.Context) error {
+	var w worker
+	w.ctx = ctx
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.TryGo(func() error {
+		_ = egCtx
+		return w.Run() // want `errgroup callback should probably not reference outer context "ctx", use the errgroup-derived context "egCtx"`
+	})
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_DerivedContext(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	w := &worker{ctx: egCtx}
+	eg.Go(w.Run)
+	eg.Go(func() error { return w.Run() })
+	return eg.Wait()
+}
+
+func Neg_StoredDerivedContext(ctx context.Context) error {
+	w := &worker{}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	w.ctx = egCtx
+	eg.Go(w.Run)
+	return eg.Wait()
+}
+
+func Neg_CreatedInsideCallback(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		w := &worker{ctx: egCtx}
+		return w.Run()
+	})
+	return eg.Wait()
+}
+
+func Neg_NoContext(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	_ = egCtx
+	w := &worker{item: 1}
+	eg.Go(w.Run)
+	return eg.Wait()
+}
+
+func Neg_NoDerivedContext(ctx context.Context) error {
+	eg := errgroup.New()
+	w := &worker{ctx: ctx}
+	eg.Go(w.Run)
+	return eg.Wait()
+}
+
+func doSmth(context.Context) error { return nil }
@@ -0,0 +1,137 @@
+package swallowed
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func Logged() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		if err := fetch(egCtx); err != nil { // want `error of fetch is checked but dropped, the callback of errgroup "eg" always returns nil so the group never sees it`
+			log.Print(err)
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+func EarlyReturn() error {
+	eg := errgroup.New()
+	eg.TryGo(func() error {
+		n, err := count() // want `error of count is checked but dropped, the callback of errgroup "eg" always returns nil so the group never sees it`
+		if err != nil {
+			return nil
+		}
+		_ = n
+
+		err = fetch(context.Background()) // want `error of fetch is checked but dropped, the callback of errgroup "eg" always returns nil so the group never sees it`
+		if nil == err {
+			log.Print("ok")
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_Returned() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		if err := fetch(egCtx); err != nil {
+			return err
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+func Neg_ReturnedWrapped() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		if err := fetch(egCtx); err != nil {
+			return wrap(err)
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+func Neg_SentOnChannel(errCh chan<- error) error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		if err := fetch(egCtx); err != nil {
+			errCh <- err
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+func Neg_StoredOutside() ([]error, error) {
+	var (
+		mu   sync.Mutex
+		errs []error
+		last error
+	)
+
+	eg := errgroup.New()
+	eg.Go(func() error {
+		if err := fetch(context.Background()); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		if err := fetch(context.Background()); err != nil {
+			log.Printf("fetch: %v", err)
+			last = err
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		if err := fetch(context.Background()); err != nil {
+			record(err)
+		}
+		return nil
+	})
+	return append(errs, last), eg.Wait()
+}
+
+func Neg_NotChecked() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		_ = fetch(egCtx)
+		return nil
+	})
+	return eg.Wait()
+}
+
+func Neg_ErrorInNestedFunc() error {
+	eg := errgroup.New()
+	eg.Go(func() error {
+		check := func() error {
+			if err := fetch(context.Background()); err != nil {
+				return err
+			}
+			return nil
+		}
+		_ = check
+		return nil
+	})
+	return eg.Wait()
+}
+
+func fetch(_ context.Context) error { return nil }
+
+func count() (int, error) { return 0, nil }
+
+func wrap(err error) error { return err }
+
+func record(error) {}
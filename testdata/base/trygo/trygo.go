@@ -0,0 +1,76 @@
+package trygo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func IgnoredWithLimit() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.SetLimit(2)
+	eg.TryGo(func() error { // want `result of TryGo on errgroup "eg" is ignored, the callback is silently dropped when the limit is reached`
+		<-egCtx.Done()
+		return nil
+	})
+	eg.Wait()
+}
+
+func BlankWithLimit() {
+	eg := errgroup.New()
+	eg.SetLimit(2)
+	_ = eg.TryGo(func() error { return nil })     // want `result of TryGo on errgroup "eg" is ignored, the callback is silently dropped when the limit is reached`
+	var _ = eg.TryGo(func() error { return nil }) // want `result of TryGo on errgroup "eg" is ignored, the callback is silently dropped when the limit is reached`
+	eg.Wait()
+}
+
+func DeferredAndGoWithLimit() {
+	eg := errgroup.New()
+	eg.SetLimit(2)
+	defer eg.TryGo(func() error { return nil }) // want `result of TryGo on errgroup "eg" is ignored, the callback is silently dropped when the limit is reached`
+	go eg.TryGo(func() error { return nil })    // want `result of TryGo on errgroup "eg" is ignored, the callback is silently dropped when the limit is reached`
+	eg.Wait()
+}
+
+func SetLimitAfterTryGo() {
+	eg := errgroup.New()
+	eg.TryGo(func() error { return nil }) // want `result of TryGo on errgroup "eg" is ignored, the callback is silently dropped when the limit is reached`
+	eg.Wait()
+	eg.SetLimit(1)
+}
+
+// --- Negative ---
+
+func Neg_Checked() error {
+	eg := errgroup.New()
+	eg.SetLimit(2)
+	if !eg.TryGo(func() error { return nil }) {
+		return errors.New("too busy")
+	}
+	return eg.Wait()
+}
+
+func Neg_Assigned() error {
+	eg := errgroup.New()
+	eg.SetLimit(2)
+	ok := eg.TryGo(func() error { return nil })
+	_, started := 1, eg.TryGo(func() error { return nil })
+	if !ok || !started {
+		return errors.New("too busy")
+	}
+	return eg.Wait()
+}
+
+func Neg_NoLimit() {
+	eg := errgroup.New()
+	eg.TryGo(func() error { return nil })
+	eg.Wait()
+}
+
+func Neg_Nolint() {
+	eg := errgroup.New()
+	eg.SetLimit(2)
+	eg.TryGo(func() error { return nil }) //nolint:errgroupctx
+	eg.Wait()
+}
@@ -0,0 +1,16 @@
+package trygo_nolimit
+
+import (
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func IgnoredWithoutLimit() {
+	eg := errgroup.New()
+	eg.TryGo(func() error { return nil }) // want `result of TryGo on errgroup "eg" is ignored, the callback is silently dropped when the limit is reached`
+	eg.Wait()
+}
+
+func Neg_Checked() bool {
+	eg := errgroup.New()
+	return eg.TryGo(func() error { return nil })
+}
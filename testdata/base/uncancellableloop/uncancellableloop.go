@@ -0,0 +1,174 @@
+package uncancellableloop
+
+import (
+	"context"
+	"time"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func Unbounded() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	eg.Go(func() error {
+		for { // want `unbounded loop in errgroup callback never observes "egCtx", it keeps running after the group is cancelled`
+			work()
+		}
+	})
+	eg.Wait()
+}
+
+func Polling(ready func() bool) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	eg.Go(func() error {
+		for !ready() { // want `polling loop in errgroup callback never observes "egCtx", it keeps running after the group is cancelled`
+			time.Sleep(time.Second)
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func RangeOverTicker() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	eg.Go(func() error {
+		for range time.Tick(time.Second) { // want `polling loop in errgroup callback never observes "egCtx", it keeps running after the group is cancelled`
+			work()
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		t := time.NewTicker(time.Second)
+		defer t.Stop()
+		for range t.C { // want `polling loop in errgroup callback never observes "egCtx", it keeps running after the group is cancelled`
+			work()
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func PollingRange(items []int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	eg.Go(func() error {
+		for range items { // want `polling loop in errgroup callback never observes "egCtx", it keeps running after the group is cancelled`
+			<-time.After(time.Second)
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func InnerLoop(ready func() bool) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		for egCtx.Err() == nil {
+			for !ready() { // want `polling loop in errgroup callback never observes "egCtx", it keeps running after the group is cancelled`
+				time.Sleep(time.Second)
+			}
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_ChecksErr() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		for {
+			if err := egCtx.Err(); err != nil {
+				return err
+			}
+			time.Sleep(time.Second)
+		}
+	})
+	return eg.Wait()
+}
+
+func Neg_Select() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	eg.Go(func() error {
+		for {
+			select {
+			case <-egCtx.Done():
+				return egCtx.Err()
+			case <-t.C:
+			}
+		}
+	})
+	eg.Wait()
+}
+
+func Neg_PassesContext() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		for {
+			if err := doSmth(egCtx); err != nil {
+				return err
+			}
+		}
+	})
+	eg.Wait()
+}
+
+func Neg_DerivedContext() {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		ctx, cancel := context.WithCancel(egCtx)
+		defer cancel()
+		for ctx.Err() == nil {
+			time.Sleep(time.Second)
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_CancellationAwareCall(p *poller) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	eg.Go(func() error {
+		for p.Poll() {
+			time.Sleep(time.Second)
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_BoundedLoop(items []int) {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	_ = egCtx
+	eg.Go(func() error {
+		for range items {
+			work()
+		}
+		return nil
+	})
+	eg.Wait()
+}
+
+func Neg_PlainGroup() {
+	eg := errgroup.New()
+	eg.Go(func() error {
+		for {
+			work()
+		}
+	})
+	eg.Wait()
+}
+
+type poller struct{}
+
+func (p *poller) Poll() bool { return false }
+
+func work() {}
+
+func doSmth(_ context.Context) error { return nil }
@@ -0,0 +1,161 @@
+package untrackedgoroutine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func Closure() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		go func() { // want `goroutine started in errgroup callback uses "egCtx" but is never joined, it may outlive the group and run with a cancelled context`
+			<-egCtx.Done()
+		}()
+		return nil
+	})
+	return eg.Wait()
+}
+
+func Call() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.TryGo(func() error {
+		go doSmth(egCtx) // want `goroutine started in errgroup callback uses "egCtx" but is never joined, it may outlive the group and run with a cancelled context`
+		return nil
+	})
+	return eg.Wait()
+}
+
+func JoinedOnOneBranch(cond bool) error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		done := make(chan struct{})
+		go func() { // want `goroutine started in errgroup callback uses "egCtx" but is never joined, it may outlive the group and run with a cancelled context`
+			defer close(done)
+			_ = doSmth(egCtx)
+		}()
+		if cond {
+			return nil
+		}
+		<-done
+		return nil
+	})
+	return eg.Wait()
+}
+
+// Waiting for cancellation or a timeout does not wait for the goroutine.
+func WaitsForDoneOrTimeout() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		go doSmth(egCtx) // want `goroutine started in errgroup callback uses "egCtx" but is never joined, it may outlive the group and run with a cancelled context`
+		<-egCtx.Done()
+		return nil
+	})
+	eg.Go(func() error {
+		go doSmth(egCtx) // want `goroutine started in errgroup callback uses "egCtx" but is never joined, it may outlive the group and run with a cancelled context`
+		<-time.After(time.Second)
+		return nil
+	})
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_BufferedChannelReceive() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		errs := make(chan error, 1)
+		go func() {
+			errs <- doSmth(egCtx)
+		}()
+		return <-errs
+	})
+	return eg.Wait()
+}
+
+func Neg_ChannelReceive() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		errs := make(chan error, 1)
+		go func() {
+			errs <- doSmth(egCtx)
+		}()
+		return <-errs
+	})
+	return eg.Wait()
+}
+
+func Neg_WaitGroup() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = doSmth(egCtx)
+		}()
+		wg.Wait()
+		return nil
+	})
+	return eg.Wait()
+}
+
+func Neg_DeferredWait() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = doSmth(egCtx)
+		}()
+		return nil
+	})
+	return eg.Wait()
+}
+
+func Neg_RangeOverChannel() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		results := make(chan error)
+		go func() {
+			defer close(results)
+			results <- doSmth(egCtx)
+		}()
+		for err := range results {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return eg.Wait()
+}
+
+func Neg_NestedGroupWait() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		inner := errgroup.New()
+		go func() {
+			inner.Go(func() error { return doSmth(egCtx) })
+		}()
+		return inner.Wait()
+	})
+	return eg.Wait()
+}
+
+func Neg_NoContext() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		go work()
+		return doSmth(egCtx)
+	})
+	return eg.Wait()
+}
+
+func doSmth(_ context.Context) error { return nil }
+
+func work() {}
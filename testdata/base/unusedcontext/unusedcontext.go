@@ -0,0 +1,124 @@
+package unusedcontext
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func NotUsedByCallbacks(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx) // want `derived context "egCtx" of errgroup "eg" is not used by any of its callbacks, the group does not need a context`
+	_ = egCtx
+
+	eg.Go(func() error {
+		return work()
+	})
+
+	return doSmth(ctx)
+}
+
+func NoCallbacks() error {
+	eg, egCtx := errgroup.WithContext(context.Background()) // want `derived context "egCtx" of errgroup "eg" is not used by any of its callbacks, the group does not need a context`
+	_ = egCtx
+
+	return eg.Wait()
+}
+
+func BlankInCallback(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx) // want `derived context "egCtx" of errgroup "eg" is not used by any of its callbacks, the group does not need a context`
+
+	eg.Go(func() error {
+		_ = egCtx
+		return work()
+	})
+
+	return eg.Wait()
+}
+
+func ExistingGroup(ctx context.Context) error {
+	eg := errgroup.New()
+	eg.Go(work)
+
+	eg, egCtx := errgroup.WithContext(ctx) // want `derived context "egCtx" of errgroup "eg" is not used by any of its callbacks, the group does not need a context`
+	_ = egCtx
+
+	return eg.Wait()
+}
+
+func ParentNotUsedElsewhere() error {
+	ctx := context.Background()
+
+	eg, egCtx := errgroup.WithContext(ctx) // want `derived context "egCtx" of errgroup "eg" is not used by any of its callbacks, the group does not need a context`
+	_ = egCtx
+
+	eg.Go(work)
+
+	return eg.Wait()
+}
+
+func BlankSharingItsLine() error {
+	eg, egCtx := errgroup.WithContext(context.Background()) // want `derived context "egCtx" of errgroup "eg" is not used by any of its callbacks, the group does not need a context`
+	_ = egCtx; eg.Go(work)
+	eg.Go(work); _ = egCtx
+	_ = egCtx // the callbacks do not need a context
+
+	return eg.Wait()
+}
+
+// --- Negative ---
+
+func Neg_UsedByCallback(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		return doSmth(egCtx)
+	})
+
+	return eg.Wait()
+}
+
+func Neg_UsedByHelper(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	f := func() error {
+		return doSmth(egCtx)
+	}
+
+	eg.Go(f)
+
+	return eg.Wait()
+}
+
+func Neg_UsedOutsideCallbacks(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	ch := produce(egCtx)
+
+	eg.Go(func() error {
+		<-ch
+		return nil
+	})
+
+	return eg.Wait()
+}
+
+func Neg_DiscardedContext(ctx context.Context) error {
+	eg, _ := errgroup.WithContext(ctx)
+
+	eg.Go(work)
+
+	return eg.Wait()
+}
+
+func Neg_Nolint(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx) //nolint:errgroupctx
+	_ = egCtx
+
+	return eg.Wait()
+}
+
+func work() error { return nil }
+
+func doSmth(_ context.Context) error { return nil }
+
+func produce(_ context.Context) <-chan struct{} { return nil }
@@ -0,0 +1,132 @@
+package wait
+
+import (
+	"context"
+	"errors"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func NeverWaited() {
+	eg, egCtx := errgroup.WithContext(context.Background()) // want `Wait is never called on errgroup "eg", its goroutines leak and their errors are lost`
+	eg.Go(func() error {
+		<-egCtx.Done()
+		return nil
+	})
+}
+
+func NeverWaitedNew() {
+	eg := errgroup.New() // want `Wait is never called on errgroup "eg", its goroutines leak and their errors are lost`
+	eg.TryGo(func() error { return nil })
+}
+
+func EarlyReturn(cond bool) error {
+	eg := errgroup.New() // want `errgroup "eg" may be left without calling Wait, its goroutines leak and their errors are lost`
+	eg.Go(func() error { return nil })
+	if cond {
+		return errors.New("early")
+	}
+	return eg.Wait()
+}
+
+func WaitOnlyInBranch(cond bool) error {
+	eg := errgroup.New() // want `errgroup "eg" may be left without calling Wait, its goroutines leak and their errors are lost`
+	eg.Go(func() error { return nil })
+	if cond {
+		return eg.Wait()
+	}
+	return nil
+}
+
+func IgnoredWait() {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	eg.Wait() // want `error returned by Wait on errgroup "eg" is ignored`
+}
+
+func IgnoredWaitBlank() {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	_ = eg.Wait() // want `error returned by Wait on errgroup "eg" is ignored`
+}
+
+func IgnoredDeferredWait() {
+	eg := errgroup.New()
+	defer eg.Wait() // want `error returned by Wait on errgroup "eg" is ignored`
+	eg.Go(func() error { return nil })
+}
+
+// --- Negative ---
+
+func Neg_Waited() error {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	return eg.Wait()
+}
+
+func Neg_ReturnBeforeGo(cond bool) error {
+	eg := errgroup.New()
+	if cond {
+		return errors.New("early")
+	}
+	eg.Go(func() error { return nil })
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Neg_PanicWithoutWait(cond bool) error {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	if cond {
+		panic("boom")
+	}
+	return eg.Wait()
+}
+
+func Neg_WaitInDeferredClosure() (err error) {
+	eg := errgroup.New()
+	defer func() {
+		err = eg.Wait()
+	}()
+	eg.Go(func() error { return nil })
+	return nil
+}
+
+func Neg_Returned() *errgroup.Group {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	return eg
+}
+
+func Neg_PassedAlong() error {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	return waitFor(eg)
+}
+
+func Neg_MethodValue() error {
+	eg := errgroup.New()
+	eg.Go(func() error { return nil })
+	wait := eg.Wait
+	return wait()
+}
+
+func Neg_NoGo() {
+	eg := errgroup.New()
+	_ = eg
+}
+
+func Neg_Loop(items []int) error {
+	for range items {
+		eg := errgroup.New()
+		eg.Go(func() error { return nil })
+		if err := eg.Wait(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func waitFor(eg *errgroup.Group) error { return eg.Wait() }
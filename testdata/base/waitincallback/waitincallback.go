@@ -0,0 +1,92 @@
+package waitincallback
+
+import (
+	"context"
+
+	"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup"
+)
+
+func WaitInOwnCallback() error {
+	eg, egCtx := errgroup.WithContext(context.Background())
+	eg.Go(func() error {
+		<-egCtx.Done()
+		return eg.Wait() // want `Wait on errgroup "eg" is called from one of its own callbacks, which deadlocks`
+	})
+	return eg.Wait()
+}
+
+func WaitInOwnTryGoCallback() {
+	eg := errgroup.New()
+	eg.TryGo(func() error {
+		return eg.Wait() // want `Wait on errgroup "eg" is called from one of its own callbacks, which deadlocks`
+	})
+}
+
+func WaitInNestedClosure() {
+	eg := errgroup.New()
+	eg.Go(func() error {
+		wait := func() error {
+			return eg.Wait() // want `Wait on errgroup "eg" is called from one of its own callbacks, which deadlocks`
+		}
+		return wait()
+	})
+}
+
+func WaitDeferredInCallback() {
+	eg := errgroup.New()
+	eg.Go(func() error {
+		defer eg.Wait() // want `Wait on errgroup "eg" is called from one of its own callbacks, which deadlocks`
+		return nil
+	})
+}
+
+func WaitInCallbackOfInnerGroup() {
+	eg := errgroup.New()
+	eg.Go(func() error {
+		inner := errgroup.New()
+		inner.Go(func() error {
+			return eg.Wait() // want `Wait on errgroup "eg" is called from one of its own callbacks, which deadlocks`
+		})
+		return inner.Wait()
+	})
+}
+
+// --- Negative ---
+
+func Neg_TripleNestedErrGroup() error {
+	ctx := context.Background()
+	eg1, egCtx1 := errgroup.WithContext(ctx)
+	eg1.Go(func() error {
+		eg2, egCtx2 := errgroup.WithContext(egCtx1)
+		eg2.Go(func() error {
+			eg3, egCtx3 := errgroup.WithContext(egCtx2)
+			eg3.Go(func() error {
+				<-egCtx3.Done()
+				return nil
+			})
+			return eg3.Wait()
+		})
+		return eg2.Wait()
+	})
+	return eg1.Wait()
+}
+
+func Neg_WaitInGoroutineOfCallback() {
+	eg := errgroup.New()
+	eg.Go(func() error {
+		go func() {
+			_ = eg.Wait()
+		}()
+		return nil
+	})
+}
+
+func Neg_WaitOtherGroup() error {
+	eg1 := errgroup.New()
+	eg2 := errgroup.New()
+	eg2.Go(func() error { return nil })
+	eg1.Go(func() error {
+		return eg2.Wait()
+	})
+	return eg1.Wait()
+}
@@ -1,8 +1,13 @@
 package testing
 
 import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
+	errgroupctxlint "github.com/m-ocean-it/errgroup-ctx-lint"
 	"github.com/m-ocean-it/errgroup-ctx-lint/analyzer"
 	"github.com/m-ocean-it/errgroup-ctx-lint/analyzer/func_visitor"
 	"golang.org/x/tools/go/analysis/analysistest"
@@ -21,3 +26,617 @@ func TestAll(t *testing.T) {
 		}),
 	)
 }
+
+func TestInventory(t *testing.T) {
+	t.Parallel()
+
+	results := analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./inventory",
+	)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	limit := func(n int64) *int64 { return &n }
+
+	want := []func_visitor.GroupReport{
+		{
+			Position:       "inventory.go:12:15",
+			Constructor:    "errgroup.WithContext",
+			Group:          "eg",
+			DerivedContext: "egCtx",
+			SetLimitCalled: true,
+			SetLimit:       limit(2),
+			GoSites:        1,
+			TryGoSites:     1,
+			WaitCalled:     true,
+			Flagged:        true,
+		},
+		{
+			Position:       "inventory.go:29:11",
+			Constructor:    "errgroup.WithContext",
+			Group:          "eg",
+			DerivedContext: "_",
+			SetLimitCalled: true,
+			GoSites:        1,
+		},
+		{
+			Position:    "inventory.go:38:8",
+			Constructor: "errgroup.New",
+			Group:       "eg",
+			GoSites:     2,
+			WaitCalled:  true,
+		},
+	}
+
+	got := results[0].Result.([]func_visitor.GroupReport)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d groups, got %d: %+v", len(want), len(got), got)
+	}
+
+	for i := range want {
+		if !strings.HasSuffix(got[i].Position, want[i].Position) {
+			t.Errorf("group %d: expected position %q, got %q", i, want[i].Position, got[i].Position)
+		}
+
+		got[i].Position = want[i].Position
+
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("group %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Explain:       "explain/explain.go:16",
+			ExplainOutput: &out,
+		}),
+		"./explain",
+	)
+
+	for _, want := range []string{
+		"explain/explain.go:16: errgroup callback at ",
+		`matched group "eg" declared at `,
+		`derived context "egCtx" declared at `,
+		`explain.go:16:18 "ctx": outer: declared at `,
+		`explain.go:16:23 "egCtx": allowed: the errgroup-derived context`,
+		`explain.go:16:30 "localCtx": allowed: declared inside the callback at `,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("explanation does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestDiagnosticDetails(t *testing.T) {
+	t.Parallel()
+
+	results := analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./related",
+	)
+	if len(results) != 1 || len(results[0].Diagnostics) != 1 {
+		t.Fatalf("expected a single diagnostic, got %+v", results)
+	}
+
+	var (
+		fset = results[0].Pass.Fset
+		diag = results[0].Diagnostics[0]
+	)
+
+	if diag.Category != func_visitor.RuleOuterContext {
+		t.Errorf("unexpected category %q", diag.Category)
+	}
+
+	if diag.URL != func_visitor.DocsURL+"#"+func_visitor.RuleOuterContext {
+		t.Errorf("unexpected URL %q", diag.URL)
+	}
+
+	if start, end := fset.Position(diag.Pos), fset.Position(diag.End); end.Column-start.Column != len("ctx") {
+		t.Errorf("diagnostic should span the identifier, got %s-%s", start, end)
+	}
+
+	wantRelated := []struct {
+		pos     string
+		message string
+	}{
+		{"related.go:12:15", `errgroup "eg" is created here`},
+		{"related.go:10:2", `outer context "ctx" is declared here`},
+	}
+
+	if len(diag.Related) != len(wantRelated) {
+		t.Fatalf("expected %d related entries, got %+v", len(wantRelated), diag.Related)
+	}
+
+	for i, want := range wantRelated {
+		got := diag.Related[i]
+
+		if pos := fset.Position(got.Pos).String(); !strings.HasSuffix(pos, want.pos) {
+			t.Errorf("related %d: expected position %q, got %q", i, want.pos, pos)
+		}
+
+		if got.Message != want.message {
+			t.Errorf("related %d: expected message %q, got %q", i, want.message, got.Message)
+		}
+	}
+}
+
+func TestAggregateByCallback(t *testing.T) {
+	t.Parallel()
+
+	results := analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			AggregateByCallback: true,
+		}),
+		"./aggregate",
+	)
+
+	// MultipleCtxArgs: the constructor and every one of the four uses.
+	if related := results[0].Diagnostics[0].Related; len(related) != 5 {
+		t.Errorf("expected 5 related entries, got %d", len(related))
+	}
+}
+
+func TestMessageTemplate(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			MessageTemplate: `[{{.Rule}}] {{.Group}}.{{.CallbackKind}} callback uses {{printf "%q" .OuterContext}} ` +
+				`instead of {{printf "%q" .DerivedContext}}, see go/style#errgroup`,
+		}),
+		"./message",
+	)
+}
+
+func TestMessageTemplateValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, tmpl := range []string{
+		"{{.OuterContext",
+		"{{.UnknownField}}",
+	} {
+		if _, err := errgroupctxlint.New(map[string]any{"message_template": tmpl}); err == nil {
+			t.Errorf("expected template %q to be rejected", tmpl)
+		}
+	}
+
+	if _, err := errgroupctxlint.New(map[string]any{"message_template": "{{.OuterContext}}"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestRuleValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, settings := range []map[string]any{
+		{"enable": []any{"no-such-rule"}},
+		{"disable": []any{"no-such-rule"}},
+	} {
+		if _, err := errgroupctxlint.New(settings); err == nil {
+			t.Errorf("expected settings %v to be rejected", settings)
+		}
+	}
+
+	if _, err := errgroupctxlint.New(map[string]any{"disable": []any{func_visitor.RuleSetLimit}}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestSetLimit(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./setlimit",
+	)
+}
+
+func TestTryGoResult(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./trygo",
+	)
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			CheckTryGoWithoutLimit: true,
+		}),
+		"./trygo_nolimit",
+	)
+}
+
+func TestWait(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Enable: []string{
+				func_visitor.RuleMissingWait,
+				func_visitor.RuleUncheckedWait,
+			},
+		}),
+		"./wait",
+	)
+}
+
+func TestWaitInCallback(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./waitincallback",
+	)
+}
+
+func TestGoAfterWait(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./goafterwait",
+	)
+}
+
+func TestBlockingChannel(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Enable: []string{func_visitor.RuleBlockingChannel},
+		}),
+		"./blockingchannel",
+	)
+}
+
+func TestUncancellableLoop(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Enable: []string{func_visitor.RuleUncancellableLoop},
+			CancellationAwareCalls: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/uncancellableloop.poller.Poll",
+			},
+		}),
+		"./uncancellableloop",
+	)
+}
+
+func TestUnusedDerivedContext(t *testing.T) {
+	t.Parallel()
+
+	analysistest.RunWithSuggestedFixes(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Enable: []string{func_visitor.RuleUnusedContext},
+		}),
+		"./unusedcontext",
+	)
+}
+
+func TestShadowContext(t *testing.T) {
+	t.Parallel()
+
+	analysistest.RunWithSuggestedFixes(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Enable: []string{func_visitor.RuleShadowContext},
+		}),
+		"./shadowcontext",
+	)
+}
+
+func TestNestedGroup(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./nestedgroup",
+	)
+}
+
+func TestDetachedRoot(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./detachedroot",
+	)
+}
+
+func TestUntrackedGoroutine(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Enable: []string{func_visitor.RuleUntrackedGo},
+		}),
+		"./untrackedgoroutine",
+	)
+}
+
+func TestUnboundedFanOut(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Enable:          []string{func_visitor.RuleUnboundedFanOut},
+			FanOutThreshold: 100,
+		}),
+		"./fanout",
+	)
+}
+
+func TestHandRolledGroup(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Enable: []string{func_visitor.RuleHandRolledGroup},
+		}),
+		"./handrolled",
+	)
+}
+
+func TestDerivedContext(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewDerivedAnalyzerWithConfig(func_visitor.Config{}),
+		"./derivedctx",
+	)
+}
+
+func TestDerivedContextDisabled(t *testing.T) {
+	t.Parallel()
+
+	results := analysistest.Run(
+		&errorRecorder{},
+		"../testdata/base",
+		analyzer.NewDerivedAnalyzerWithConfig(func_visitor.Config{
+			Disable: []string{func_visitor.RuleDerivedContext},
+		}),
+		"./derivedctx",
+	)
+
+	for _, result := range results {
+		for _, d := range result.Diagnostics {
+			t.Errorf("unexpected diagnostic: %s", d.Message)
+		}
+	}
+
+	for _, tc := range []struct {
+		disable []any
+		want    []string
+	}{
+		{nil, []string{analyzer.Name, analyzer.DerivedName}},
+		{[]any{func_visitor.RuleDerivedContext}, []string{analyzer.Name}},
+	} {
+		plugin, err := errgroupctxlint.New(map[string]any{"disable": tc.disable})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		analyzers, err := plugin.BuildAnalyzers()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		var names []string
+		for _, a := range analyzers {
+			names = append(names, a.Name)
+		}
+
+		if !reflect.DeepEqual(names, tc.want) {
+			t.Errorf("disable %v: got analyzers %v, want %v", tc.disable, names, tc.want)
+		}
+	}
+}
+
+// errorRecorder collects the errors of analysistest.Run, for tests that
+// expect the want comments of a package not to be matched.
+type errorRecorder struct {
+	errors []string
+}
+
+func (r *errorRecorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestSpawners(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Spawners: []func_visitor.Spawner{
+				{Func: "sync.WaitGroup.Go", Arg: 0},
+				{Func: "github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/spawners.pool.Submit", Arg: 1},
+			},
+		}),
+		"./spawners",
+	)
+}
+
+func TestHelperClosure(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./helperclosure",
+	)
+}
+
+func TestStructCapture(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./structcapture",
+	)
+}
+
+func TestCallbackFactory(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+		}),
+		"./factory",
+	)
+}
+
+func TestSwallowedError(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(
+		t,
+		"../testdata/base",
+		analyzer.NewAnalyzerWithConfig(func_visitor.Config{
+			ErrgroupPackagePaths: []string{
+				"github.com/m-ocean-it/errgroup-ctx-lint/testdata/base/errgroup",
+			},
+			Enable: []string{func_visitor.RuleSwallowedError},
+		}),
+		"./swallowed",
+	)
+}